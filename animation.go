@@ -0,0 +1,230 @@
+package dom
+
+import (
+	"errors"
+	"math"
+	"strconv"
+	"time"
+)
+
+/*
+Animates elements with the Web Animations API, so transitions can be written
+in Go instead of CSS:
+
+	anim := panel.Animate([]KeyframeT{
+		{"opacity": "0", "transform": "translateY(-8px)"},
+		{"opacity": "1", "transform": "none"},
+	}, AnimationOptionsT{Duration: 200 * time.Millisecond, Easing: "ease-out", Fill: AnimationFill_Forwards})
+
+	if err := <-anim.Finished(); err == nil { ... }
+*/
+
+// KeyframeT maps CSS properties, named in JavaScript camelCase like
+// "backgroundColor", to their values at one point of an animation. The
+// special keys "offset" (from 0 to 1), "easing" and "composite" are passed
+// through as well.
+// https://developer.mozilla.org/en-US/docs/Web/API/Web_Animations_API/Keyframe_Formats
+type KeyframeT map[string]string
+
+func (k KeyframeT) toMap() map[string]any {
+	m := make(map[string]any, len(k))
+	for name, value := range k {
+		if name == "offset" {
+			if offset, err := strconv.ParseFloat(value, 64); err == nil {
+				m[name] = offset
+				continue
+			}
+		}
+		m[name] = value
+	}
+	return m
+}
+
+/*
+https://developer.mozilla.org/en-US/docs/Web/API/KeyframeEffect/KeyframeEffect#fill
+*/
+type AnimationFill string
+
+const (
+	AnimationFill_None      AnimationFill = "none"
+	AnimationFill_Forwards  AnimationFill = "forwards" // keep the last keyframe's styles after the animation ends
+	AnimationFill_Backwards AnimationFill = "backwards"
+	AnimationFill_Both      AnimationFill = "both"
+	AnimationFill_Auto      AnimationFill = "auto"
+)
+
+/*
+https://developer.mozilla.org/en-US/docs/Web/API/KeyframeEffect/KeyframeEffect#direction
+*/
+type AnimationDirection string
+
+const (
+	AnimationDirection_Normal           AnimationDirection = "normal"
+	AnimationDirection_Reverse          AnimationDirection = "reverse"
+	AnimationDirection_Alternate        AnimationDirection = "alternate"
+	AnimationDirection_AlternateReverse AnimationDirection = "alternate-reverse"
+)
+
+// AnimationOptionsT describes the timing of an animation. Empty fields keep
+// the browser default.
+// https://developer.mozilla.org/en-US/docs/Web/API/Element/animate#options
+type AnimationOptionsT struct {
+	ID         string
+	Duration   time.Duration
+	Delay      time.Duration
+	EndDelay   time.Duration
+	Easing     string  // a CSS easing function, such as "ease-in-out" or "cubic-bezier(0.2, 0, 0, 1)"
+	Iterations float64 // 0 plays once, math.Inf(1) repeats forever
+	Direction  AnimationDirection
+	Fill       AnimationFill
+}
+
+func (o AnimationOptionsT) toMap() map[string]any {
+	m := map[string]any{
+		"duration": durationMilliseconds(o.Duration),
+	}
+	if o.ID != "" {
+		m["id"] = o.ID
+	}
+	if o.Delay != 0 {
+		m["delay"] = durationMilliseconds(o.Delay)
+	}
+	if o.EndDelay != 0 {
+		m["endDelay"] = durationMilliseconds(o.EndDelay)
+	}
+	if o.Easing != "" {
+		m["easing"] = o.Easing
+	}
+	if o.Iterations != 0 {
+		m["iterations"] = o.Iterations
+	}
+	if o.Direction != "" {
+		m["direction"] = string(o.Direction)
+	}
+	if o.Fill != "" {
+		m["fill"] = string(o.Fill)
+	}
+	return m
+}
+
+func durationMilliseconds(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
+
+func millisecondsDuration(ms float64) time.Duration {
+	return time.Duration(math.Round(ms * float64(time.Millisecond)))
+}
+
+////
+////
+////
+
+// ErrAnimationCanceled is sent on the Finished channel of a canceled animation.
+var ErrAnimationCanceled = errors.New("animation canceled")
+
+/*
+https://developer.mozilla.org/en-US/docs/Web/API/Animation/playState
+*/
+type AnimationPlayState string
+
+const (
+	AnimationPlayState_Idle     AnimationPlayState = "idle"
+	AnimationPlayState_Running  AnimationPlayState = "running"
+	AnimationPlayState_Paused   AnimationPlayState = "paused"
+	AnimationPlayState_Finished AnimationPlayState = "finished"
+)
+
+// https://developer.mozilla.org/en-US/docs/Web/API/Animation
+type AnimationI interface {
+	Underlying() ValueI
+
+	ID() string
+	PlayState() AnimationPlayState // https://developer.mozilla.org/en-US/docs/Web/API/Animation/playState
+	Play()                         // https://developer.mozilla.org/en-US/docs/Web/API/Animation/play
+	Pause()                        // https://developer.mozilla.org/en-US/docs/Web/API/Animation/pause
+	Reverse()                      // https://developer.mozilla.org/en-US/docs/Web/API/Animation/reverse
+	Cancel()                       // https://developer.mozilla.org/en-US/docs/Web/API/Animation/cancel
+	Finish()                       // https://developer.mozilla.org/en-US/docs/Web/API/Animation/finish
+	CurrentTime() time.Duration    // https://developer.mozilla.org/en-US/docs/Web/API/Animation/currentTime
+	SetCurrentTime(time.Duration)  // https://developer.mozilla.org/en-US/docs/Web/API/Animation/currentTime
+	PlaybackRate() float64         // https://developer.mozilla.org/en-US/docs/Web/API/Animation/playbackRate
+	SetPlaybackRate(float64)       // https://developer.mozilla.org/en-US/docs/Web/API/Animation/playbackRate
+	CommitStyles()                 // https://developer.mozilla.org/en-US/docs/Web/API/Animation/commitStyles
+	Finished() <-chan error        // https://developer.mozilla.org/en-US/docs/Web/API/Animation/finished
+}
+
+type animationS struct {
+	ValueI
+}
+
+var _ AnimationI = animationS{}
+
+func NewAnimation(val ValueI) animationS {
+	return animationS{ValueI: val}
+}
+
+// Animate starts an animation of the element between the keyframes.
+// https://developer.mozilla.org/en-US/docs/Web/API/Element/animate
+func (e *elementS) Animate(keyframes []KeyframeT, opts AnimationOptionsT) AnimationI {
+	frames := make([]any, 0, len(keyframes))
+	for _, k := range keyframes {
+		frames = append(frames, k.toMap())
+	}
+	return NewAnimation(e.Call("animate", frames, opts.toMap()))
+}
+
+// GetAnimations returns the animations of the element that have not finished.
+// https://developer.mozilla.org/en-US/docs/Web/API/Element/getAnimations
+func (e *elementS) GetAnimations() []AnimationI {
+	var out []AnimationI
+	for _, val := range arrayToObjects(e.Call("getAnimations")) {
+		out = append(out, NewAnimation(val))
+	}
+	return out
+}
+
+func (a animationS) Underlying() ValueI { return a.ValueI }
+
+func (a animationS) ID() string { return a.Get("id").String() }
+func (a animationS) PlayState() AnimationPlayState {
+	return AnimationPlayState(a.Get("playState").String())
+}
+func (a animationS) Play()                        { a.Call("play") }
+func (a animationS) Pause()                       { a.Call("pause") }
+func (a animationS) Reverse()                     { a.Call("reverse") }
+func (a animationS) Cancel()                      { a.Call("cancel") }
+func (a animationS) Finish()                      { a.Call("finish") }
+func (a animationS) CommitStyles()                { a.Call("commitStyles") }
+func (a animationS) PlaybackRate() float64        { return a.Get("playbackRate").Float() }
+func (a animationS) SetPlaybackRate(rate float64) { a.Set("playbackRate", rate) }
+
+// CurrentTime is 0 for an animation that is not playing.
+func (a animationS) CurrentTime() time.Duration {
+	t := a.Get("currentTime")
+	if t.IsNull() || t.IsUndefined() {
+		return 0
+	}
+	return millisecondsDuration(t.Float())
+}
+
+func (a animationS) SetCurrentTime(t time.Duration) {
+	a.Set("currentTime", durationMilliseconds(t))
+}
+
+// Finished returns a channel that receives nil when the current run of the
+// animation finishes, or ErrAnimationCanceled when it is canceled. A new run,
+// after Play or Reverse on a finished animation, needs a new call. The
+// callbacks behind the channel are released when it receives, so avoid
+// calling it on an animation that repeats forever and is never canceled.
+func (a animationS) Finished() <-chan error {
+	ch := make(chan error, 1)
+	result := awaitPromise(a.Get("finished"))
+	go func() {
+		if (<-result).err != nil {
+			ch <- ErrAnimationCanceled
+		} else {
+			ch <- nil
+		}
+	}()
+	return ch
+}
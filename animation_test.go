@@ -0,0 +1,40 @@
+package dom
+
+import (
+	"math"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestKeyframeToMap(t *testing.T) {
+	k := KeyframeT{"opacity": "0.5", "offset": "0.25", "easing": "ease-in"}
+	expected := map[string]any{"opacity": "0.5", "offset": 0.25, "easing": "ease-in"}
+	if found := k.toMap(); !reflect.DeepEqual(expected, found) {
+		t.Errorf("expected: %+v but found: %+v\n", expected, found)
+	}
+}
+
+func TestAnimationOptionsToMap(t *testing.T) {
+	opts := AnimationOptionsT{
+		Duration:   1500 * time.Microsecond,
+		Delay:      time.Second,
+		Iterations: math.Inf(1),
+		Direction:  AnimationDirection_Alternate,
+		Fill:       AnimationFill_Forwards,
+	}
+	expected := map[string]any{
+		"duration":   1.5,
+		"delay":      1000.0,
+		"iterations": math.Inf(1),
+		"direction":  "alternate",
+		"fill":       "forwards",
+	}
+	if found := opts.toMap(); !reflect.DeepEqual(expected, found) {
+		t.Errorf("expected: %+v but found: %+v\n", expected, found)
+	}
+
+	if found := millisecondsDuration(1.5); found != 1500*time.Microsecond {
+		t.Errorf("expected: %v but found: %v\n", 1500*time.Microsecond, found)
+	}
+}
@@ -0,0 +1,362 @@
+package dom
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+/*
+Accessibility. ARIA attributes with typed setters, and an audit that walks
+a tree looking for the most common problems:
+
+	menu.SetRole(AriaRole_Menu)
+	toggle.SetAriaExpanded(false)
+	toggle.SetAriaDescribedBy(hint)
+	status.SetAriaLive(AriaLive_Polite)
+
+	for _, issue := range AuditAccessibility(Body) {
+		fmt.Println(issue)
+	}
+
+The audit only uses ElementI methods, so it also runs against the
+simulated backend and fakes in tests.
+*/
+
+// https://developer.mozilla.org/en-US/docs/Web/Accessibility/ARIA/Roles
+type AriaRole string
+
+const (
+	AriaRole_Unset        AriaRole = ""     // no role attribute, so the element keeps its own semantics
+	AriaRole_None         AriaRole = "none" // the same as AriaRole_Presentation
+	AriaRole_Alert        AriaRole = "alert"
+	AriaRole_AlertDialog  AriaRole = "alertdialog"
+	AriaRole_Banner       AriaRole = "banner"
+	AriaRole_Button       AriaRole = "button"
+	AriaRole_Checkbox     AriaRole = "checkbox"
+	AriaRole_Dialog       AriaRole = "dialog"
+	AriaRole_Grid         AriaRole = "grid"
+	AriaRole_GridCell     AriaRole = "gridcell"
+	AriaRole_Link         AriaRole = "link"
+	AriaRole_List         AriaRole = "list"
+	AriaRole_ListBox      AriaRole = "listbox"
+	AriaRole_ListItem     AriaRole = "listitem"
+	AriaRole_Main         AriaRole = "main"
+	AriaRole_Menu         AriaRole = "menu"
+	AriaRole_MenuBar      AriaRole = "menubar"
+	AriaRole_MenuItem     AriaRole = "menuitem"
+	AriaRole_Navigation   AriaRole = "navigation"
+	AriaRole_Option       AriaRole = "option"
+	AriaRole_Presentation AriaRole = "presentation" // hides the element's own semantics, such as a table used for layout
+	AriaRole_ProgressBar  AriaRole = "progressbar"
+	AriaRole_Radio        AriaRole = "radio"
+	AriaRole_RadioGroup   AriaRole = "radiogroup"
+	AriaRole_Region       AriaRole = "region"
+	AriaRole_Search       AriaRole = "search"
+	AriaRole_Slider       AriaRole = "slider"
+	AriaRole_Status       AriaRole = "status"
+	AriaRole_Switch       AriaRole = "switch"
+	AriaRole_Tab          AriaRole = "tab"
+	AriaRole_TabList      AriaRole = "tablist"
+	AriaRole_TabPanel     AriaRole = "tabpanel"
+	AriaRole_TextBox      AriaRole = "textbox"
+	AriaRole_Toolbar      AriaRole = "toolbar"
+	AriaRole_Tooltip      AriaRole = "tooltip"
+	AriaRole_Tree         AriaRole = "tree"
+	AriaRole_TreeItem     AriaRole = "treeitem"
+)
+
+// https://developer.mozilla.org/en-US/docs/Web/Accessibility/ARIA/Attributes/aria-live
+type AriaLive string
+
+const (
+	AriaLive_Off       AriaLive = "off"
+	AriaLive_Polite    AriaLive = "polite"    // announced when the user is idle
+	AriaLive_Assertive AriaLive = "assertive" // announced straight away, for urgent messages only
+)
+
+// SetRole sets the role attribute, or removes it for AriaRole_Unset.
+func (e *elementS) SetRole(role AriaRole) {
+	e.setOrRemoveAttribute("role", string(role))
+}
+
+func (e *elementS) Role() AriaRole {
+	return AriaRole(e.GetAttribute("role"))
+}
+
+// SetAriaLabel names the element for assistive technology when it has no
+// visible text, such as an icon button. An empty label removes it.
+func (e *elementS) SetAriaLabel(label string) {
+	e.setOrRemoveAttribute("aria-label", label)
+}
+
+func (e *elementS) AriaLabel() string {
+	return e.GetAttribute("aria-label")
+}
+
+// SetAriaLabelledBy names the element with the text of labels, which need
+// ids. No labels removes the attribute.
+func (e *elementS) SetAriaLabelledBy(labels ...ElementI) {
+	e.setOrRemoveAttribute("aria-labelledby", joinIDs(labels))
+}
+
+// AriaLabelledBy returns the ids of the labels.
+func (e *elementS) AriaLabelledBy() []string {
+	return strings.Fields(e.GetAttribute("aria-labelledby"))
+}
+
+// SetAriaDescribedBy describes the element with the text of descriptions,
+// such as a hint or an error message, which need ids. No descriptions
+// removes the attribute.
+func (e *elementS) SetAriaDescribedBy(descriptions ...ElementI) {
+	e.setOrRemoveAttribute("aria-describedby", joinIDs(descriptions))
+}
+
+// AriaDescribedBy returns the ids of the descriptions.
+func (e *elementS) AriaDescribedBy() []string {
+	return strings.Fields(e.GetAttribute("aria-describedby"))
+}
+
+// SetAriaExpanded tells whether the content the element controls, such as
+// a menu or a disclosure, is shown.
+func (e *elementS) SetAriaExpanded(expanded bool) {
+	e.SetAttribute("aria-expanded", fmt.Sprint(expanded))
+}
+
+func (e *elementS) AriaExpanded() bool {
+	return e.GetAttribute("aria-expanded") == "true"
+}
+
+// SetAriaSelected marks the selected tab, option or grid cell.
+func (e *elementS) SetAriaSelected(selected bool) {
+	e.SetAttribute("aria-selected", fmt.Sprint(selected))
+}
+
+func (e *elementS) AriaSelected() bool {
+	return e.GetAttribute("aria-selected") == "true"
+}
+
+// SetAriaLive makes the element a live region, whose changes are announced.
+// The region has to be in the page before the text that should be
+// announced is put in it.
+func (e *elementS) SetAriaLive(live AriaLive) {
+	e.setOrRemoveAttribute("aria-live", string(live))
+}
+
+func (e *elementS) AriaLive() AriaLive {
+	return AriaLive(e.GetAttribute("aria-live"))
+}
+
+func (e *elementS) setOrRemoveAttribute(name, value string) {
+	if value == "" {
+		e.RemoveAttribute(name)
+		return
+	}
+	e.SetAttribute(name, value)
+}
+
+func joinIDs(elements []ElementI) string {
+	ids := make([]string, 0, len(elements))
+	for _, e := range elements {
+		if id := e.ID(); id != "" {
+			ids = append(ids, id)
+		}
+	}
+	return strings.Join(ids, " ")
+}
+
+////
+////
+////
+
+// AuditRule names what an AccessibilityIssueT breaks.
+type AuditRule string
+
+const (
+	AuditRule_ImageAlt     AuditRule = "image-alt"     // an image without alt text; use alt="" for decorative images
+	AuditRule_ButtonName   AuditRule = "button-name"   // a button without text, image alt text, aria-label, aria-labelledby or title
+	AuditRule_InputLabel   AuditRule = "input-label"   // a form control without a label
+	AuditRule_TableHeaders AuditRule = "table-headers" // a data table without th cells
+)
+
+// AccessibilityIssueT is a problem found by AuditAccessibility.
+type AccessibilityIssueT struct {
+	Element ElementI
+	Rule    AuditRule
+	Message string
+}
+
+func (i AccessibilityIssueT) String() string {
+	return fmt.Sprintf("%s %s: %s", i.Rule, describeElement(i.Element), i.Message)
+}
+
+// describeElement identifies an element in a message, such as img#logo.
+func describeElement(e ElementI) string {
+	tag := strings.ToLower(e.TagName())
+	if id := e.ID(); id != "" {
+		return tag + "#" + id
+	}
+	return tag
+}
+
+// AuditAccessibility walks root and its descendants and returns the
+// problems found, in document order. Children in the page are audited
+// whether or not they were added through Go. Elements hidden with
+// aria-hidden are skipped.
+func AuditAccessibility(root ElementI) []AccessibilityIssueT {
+	a := &auditS{labelFor: map[string]bool{}}
+	a.walk(root, false)
+
+	// a label may come after its control, so controls are checked at the end
+	var issues []AccessibilityIssueT
+	for _, issue := range a.issues {
+		if issue.Rule == AuditRule_InputLabel && a.labelFor[issue.Element.ID()] {
+			continue
+		}
+		issues = append(issues, issue)
+	}
+	return issues
+}
+
+type auditS struct {
+	issues   []AccessibilityIssueT
+	labelFor map[string]bool // ids named by the for attribute of a label
+}
+
+func (a *auditS) report(e ElementI, rule AuditRule, message string) {
+	a.issues = append(a.issues, AccessibilityIssueT{Element: e, Rule: rule, Message: message})
+}
+
+func (a *auditS) walk(e ElementI, inLabel bool) {
+	tag := strings.ToLower(e.TagName())
+	if tag == "" || e.GetAttribute("aria-hidden") == "true" {
+		// text and comments, or nothing assistive technology sees
+		return
+	}
+	role := AriaRole(e.GetAttribute("role"))
+
+	switch tag {
+	case "label":
+		inLabel = true
+		if id := e.GetAttribute("for"); id != "" {
+			a.labelFor[id] = true
+		}
+	case "img":
+		if !e.HasAttribute("alt") && role != AriaRole_Presentation && role != AriaRole_None {
+			a.report(e, AuditRule_ImageAlt, "image has no alt text")
+		}
+	case "button":
+		a.checkButton(e)
+	case "input":
+		a.checkInput(e, inLabel)
+	case "select", "textarea":
+		a.checkControl(e, inLabel)
+	case "table":
+		if role != AriaRole_Presentation && role != AriaRole_None && !hasDescendant(e, "th") {
+			a.report(e, AuditRule_TableHeaders, "table has no header cells")
+		}
+	default:
+		if role == AriaRole_Button {
+			a.checkButton(e)
+		}
+	}
+
+	for _, child := range auditChildren(e) {
+		a.walk(child, inLabel)
+	}
+}
+
+func (a *auditS) checkButton(e ElementI) {
+	if !hasOwnName(e) && strings.TrimSpace(e.TextContent()) == "" && !hasImageAlt(e) {
+		a.report(e, AuditRule_ButtonName, "button has no accessible name")
+	}
+}
+
+func (a *auditS) checkInput(e ElementI, inLabel bool) {
+	switch strings.ToLower(e.GetAttribute("type")) {
+	case "hidden", "submit", "reset":
+		// not shown, or named by the browser
+	case "button":
+		if !hasOwnName(e) && e.GetAttribute("value") == "" {
+			a.report(e, AuditRule_ButtonName, "button has no accessible name")
+		}
+	case "image":
+		if e.GetAttribute("alt") == "" && !hasOwnName(e) {
+			a.report(e, AuditRule_ImageAlt, "image button has no alt text")
+		}
+	default:
+		a.checkControl(e, inLabel)
+	}
+}
+
+// checkControl reports a form control that is not inside a label and is not
+// named otherwise. Controls named by a label's for attribute are dropped
+// once the whole tree has been seen.
+func (a *auditS) checkControl(e ElementI, inLabel bool) {
+	if inLabel || hasOwnName(e) {
+		return
+	}
+	a.report(e, AuditRule_InputLabel, "form control has no label")
+}
+
+// hasOwnName reports whether the element is named by its attributes.
+func hasOwnName(e ElementI) bool {
+	for _, attr := range []string{"aria-label", "aria-labelledby", "title"} {
+		if strings.TrimSpace(e.GetAttribute(attr)) != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// hasImageAlt reports whether an image inside the element has alt text,
+// which names the element, as with an icon button.
+func hasImageAlt(e ElementI) bool {
+	for _, child := range auditChildren(e) {
+		if child.GetAttribute("aria-hidden") == "true" {
+			continue
+		}
+		if strings.ToLower(child.TagName()) == "img" && strings.TrimSpace(child.GetAttribute("alt")) != "" {
+			return true
+		}
+		if hasImageAlt(child) {
+			return true
+		}
+	}
+	return false
+}
+
+func hasDescendant(e ElementI, tag string) bool {
+	for _, child := range auditChildren(e) {
+		if strings.ToLower(child.TagName()) == tag || hasDescendant(child, tag) {
+			return true
+		}
+	}
+	return false
+}
+
+// auditChildren returns the element's children in the page, so children
+// added outside of Go are audited too, using the tracked wrapper of those
+// Go knows. When the page has none to report, as with the simulated backend
+// and fakes, the tracked children are returned.
+func auditChildren(e ElementI) []ElementI {
+	tracked := e.ChildNodes()
+	if !e.HasChildNodes() {
+		return tracked
+	}
+	page := nodeListToObjects(e.Underlying().Get("children"))
+	if len(page) == 0 {
+		return tracked
+	}
+
+	out := make([]ElementI, 0, len(page))
+	for _, val := range page {
+		i := slices.IndexFunc(tracked, func(child ElementI) bool { return child.Underlying().Equal(val) })
+		if i >= 0 {
+			out = append(out, tracked[i])
+		} else {
+			out = append(out, observedNode(val))
+		}
+	}
+	return out
+}
@@ -0,0 +1,72 @@
+package dom
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestAuditAccessibility(t *testing.T) {
+	root := newNode(fakeNode("div", nil,
+		fakeNode("img", map[string]any{"id": "logo"}),
+		fakeNode("img", map[string]any{"alt": ""}),
+		fakeNode("img", map[string]any{"aria-hidden": "true"}),
+		fakeNode("img", map[string]any{"role": string(AriaRole_None)}),
+		fakeNode("button", map[string]any{"id": "close"}),
+		fakeNode("button", map[string]any{"aria-label": "Close"}),
+		fakeNode("button", map[string]any{"textContent": "Save"}),
+		fakeNode("button", nil, fakeNode("span", nil, fakeNode("img", map[string]any{"alt": "Print"}))),
+		fakeNode("button", map[string]any{"id": "icon"}, fakeNode("img", map[string]any{"alt": ""})),
+		fakeNode("input", map[string]any{"id": "name"}),
+		fakeNode("input", map[string]any{"id": "email"}),
+		fakeNode("label", map[string]any{"for": "email"}),
+		fakeNode("label", nil, fakeNode("input", map[string]any{"type": "checkbox"})),
+		fakeNode("input", map[string]any{"type": "hidden"}),
+		fakeNode("table", map[string]any{"id": "data"}, fakeNode("tr", nil, fakeNode("td", nil))),
+		fakeNode("table", nil, fakeNode("tr", nil, fakeNode("th", nil))),
+		fakeNode("table", map[string]any{"role": "presentation"}),
+	))
+
+	var got []string
+	for _, issue := range AuditAccessibility(root) {
+		got = append(got, issue.String())
+	}
+	expected := []string{
+		"image-alt img#logo: image has no alt text",
+		"button-name button#close: button has no accessible name",
+		"button-name button#icon: button has no accessible name",
+		"input-label input#name: form control has no label",
+		"table-headers table#data: table has no header cells",
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected: %+v but found: %+v\n", expected, got)
+	}
+}
+
+func TestAuditAccessibilityOfNewTable(t *testing.T) {
+	table := NewTable()
+	table.AddRow().AddDataCell()
+
+	issues := AuditAccessibility(table)
+	if len(issues) != 1 || issues[0].Rule != AuditRule_TableHeaders {
+		t.Errorf("expected: one %+v issue but found: %+v\n", AuditRule_TableHeaders, issues)
+	}
+
+	header := NewTable()
+	header.AddRow().AddHeaderCell()
+	if issues := AuditAccessibility(header); len(issues) != 0 {
+		t.Errorf("expected: no issues but found: %+v\n", issues)
+	}
+}
+
+func TestAuditAccessibilityOfMixedTree(t *testing.T) {
+	// a button added through Go next to an image added by other code
+	buttonVal := fakeNode("button", nil)
+	panel := newNode(fakeNode("div", nil, buttonVal, fakeNode("img", nil)))
+	button := newNode(buttonVal)
+	panel.AppendChild(button)
+
+	issues := AuditAccessibility(panel)
+	if len(issues) != 2 || issues[0].Element != button || issues[1].Rule != AuditRule_ImageAlt {
+		t.Errorf("expected: the tracked button and the image but found: %+v\n", issues)
+	}
+}
@@ -0,0 +1,145 @@
+package dom
+
+/*
+A declarative way to build element trees in one pass:
+
+	El("div", Class("panel"), Attr("role", "tab"), Style("padding", "4px"),
+		OnClick(func(e EventI) { ... }),
+		Children(
+			El("span", Text("Name")),
+			El("button", Text("Save")),
+		),
+	)
+*/
+
+// ElOption configures an element while it is built by El.
+type ElOption func(ElementI)
+
+// El creates an element of type tag and applies the options in order.
+func El(tag string, opts ...ElOption) ElementI {
+	e := Doc.CreateElement(tag)
+	for _, opt := range opts {
+		if opt != nil {
+			opt(e)
+		}
+	}
+	return e
+}
+
+// Options groups several options into one so they can be shared between elements.
+func Options(opts ...ElOption) ElOption {
+	return func(e ElementI) {
+		for _, opt := range opts {
+			if opt != nil {
+				opt(e)
+			}
+		}
+	}
+}
+
+// Apply runs fn against the element. Useful for anything without a dedicated option.
+func Apply(fn func(ElementI)) ElOption {
+	return fn
+}
+
+// Ref stores the element being built into *target.
+func Ref(target *ElementI) ElOption {
+	return func(e ElementI) {
+		*target = e
+	}
+}
+
+func ID(id string) ElOption {
+	return func(e ElementI) {
+		e.SetID(id)
+	}
+}
+
+// Class adds one or more tokens to the element's classList.
+func Class(tokens ...string) ElOption {
+	return func(e ElementI) {
+		classes := e.Class()
+		for _, token := range tokens {
+			classes.Add(token)
+		}
+	}
+}
+
+func Attr(name, value string) ElOption {
+	return func(e ElementI) {
+		e.SetAttribute(name, value)
+	}
+}
+
+// Attrs sets every attribute in the map.
+func Attrs(attrs map[string]string) ElOption {
+	return func(e ElementI) {
+		for name, value := range attrs {
+			e.SetAttribute(name, value)
+		}
+	}
+}
+
+// Style sets a single style property. The name is the JavaScript property
+// name, so use "backgroundColor" rather than "background-color".
+func Style(name, value string) ElOption {
+	return func(e ElementI) {
+		e.Style().Set(name, value)
+	}
+}
+
+// Styled hands the element's CSSStyleI to fn, so the chained style helpers can be used.
+func Styled(fn func(CSSStyleI)) ElOption {
+	return func(e ElementI) {
+		fn(e.Style())
+	}
+}
+
+// FlexBox makes the element a flex container and hands the flex helpers to fn.
+func FlexBox(fn func(CSSStyleFlexBoxI)) ElOption {
+	return func(e ElementI) {
+		flex := e.Style().FlexBox()
+		if fn != nil {
+			fn(flex)
+		}
+	}
+}
+
+func Title(title string) ElOption {
+	return func(e ElementI) {
+		e.SetTitle(title)
+	}
+}
+
+// Text appends a text node. The text is never parsed as HTML.
+func Text(text string) ElOption {
+	return func(e ElementI) {
+		e.AppendChild(Doc.CreateTextNode(text))
+	}
+}
+
+// Children appends the children with a single DOM insertion. Nil children are skipped.
+func Children(children ...ElementI) ElOption {
+	return func(e ElementI) {
+		nonNil := make([]ElementI, 0, len(children))
+		for _, child := range children {
+			if child != nil {
+				nonNil = append(nonNil, child)
+			}
+		}
+		e.AppendChildren(nonNil...)
+	}
+}
+
+// On adds an event listener through the element's AddEventListener, so it is
+// tracked and removed along with the element.
+func On(typ string, listener func(EventI)) ElOption {
+	return func(e ElementI) {
+		e.AddEventListener(typ, false, listener)
+	}
+}
+
+func OnClick(listener func(EventI)) ElOption   { return On("click", listener) }
+func OnInput(listener func(EventI)) ElOption   { return On("input", listener) }
+func OnChange(listener func(EventI)) ElOption  { return On("change", listener) }
+func OnKeyDown(listener func(EventI)) ElOption { return On("keydown", listener) }
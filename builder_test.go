@@ -0,0 +1,71 @@
+package dom
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestEl(t *testing.T) {
+	child := NewElement(valueS{})
+	var ref ElementI
+	var applied []string
+	e := El("div",
+		nil,
+		Ref(&ref),
+		Apply(func(ElementI) { applied = append(applied, "first") }),
+		Options(nil, Apply(func(ElementI) { applied = append(applied, "second") })),
+		Children(child, nil),
+		Text("Name"),
+	)
+
+	if e.TagName() != "DIV" || ref != e || !reflect.DeepEqual([]string{"first", "second"}, applied) {
+		t.Errorf("expected: a div with the options applied in order but found: %+v and %+v\n", e.TagName(), applied)
+	}
+	// the children come in order, nil ones skipped, then the text
+	children := e.ChildNodes()
+	if len(children) != 2 || children[0] != child {
+		t.Fatalf("expected: the child and a text node but found: %+v\n", children)
+	}
+	if _, ok := children[1].(TextNodeI); !ok {
+		t.Errorf("expected: a text node but found: %+v\n", reflect.TypeOf(children[1]))
+	}
+	e.Remove()
+}
+
+func TestBuilderOptions(t *testing.T) {
+	classes, style := fakeNode("", nil), fakeNode("", nil)
+	val := fakeNode("div", map[string]any{"classList": classes, "style": style})
+	e := newNode(val)
+
+	Options(
+		ID("panel"),
+		Class("a", "b"),
+		Attr("role", "tab"),
+		Attrs(map[string]string{"data-x": "1"}),
+		Style("padding", "4px"),
+		Title("Panel"),
+	)(e)
+
+	expected := map[string]any{"id": "panel", "role": "tab", "data-x": "1", "title": "Panel"}
+	for name, value := range expected {
+		if val.props[name] != value {
+			t.Errorf("%s: expected: %+v but found: %+v\n", name, value, val.props[name])
+		}
+	}
+	if classes.props["value"] != "a b" || style.props["padding"] != "4px" {
+		t.Errorf("expected: classes a b and padding 4px but found: %+v and %+v\n", classes.props["value"], style.props["padding"])
+	}
+	e.Remove()
+}
+
+func TestBuilderListeners(t *testing.T) {
+	val := fakeNode("button", nil)
+	clicks := 0
+	OnClick(func(EventI) { clicks++ })(newNode(val))
+
+	val.dispatch("click", val)
+	val.dispatch("input", val)
+	if clicks != 1 {
+		t.Errorf("expected: %+v but found: %+v\n", 1, clicks)
+	}
+}
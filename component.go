@@ -0,0 +1,186 @@
+package dom
+
+import "sync"
+
+/*
+A component renders a VNode tree from its own state. MountComponent renders
+it into a container element and takes care of calling the lifecycle hooks
+and of re-rendering when the state changes.
+
+	type counter struct {
+		dom.BaseComponent
+		count int
+	}
+
+	func (c *counter) Render() *dom.VNode {
+		return dom.NewVNode("button", dom.NewVText(strconv.Itoa(c.count))).
+			On("click", func(dom.EventI) { c.SetState(func() { c.count++ }) })
+	}
+
+	dom.MountComponent(dom.Body, &counter{})
+*/
+
+type ComponentI interface {
+	Render() *VNode
+	Mount()   // called once, after the first render is in the container, which may not be in the document yet
+	Update()  // called after every re-render
+	Unmount() // called before the component's elements are removed
+}
+
+// componentBaseI is implemented by anything embedding BaseComponent.
+type componentBaseI interface {
+	setMounted(m *MountedS)
+}
+
+// BaseComponent provides no-op lifecycle hooks and state handling.
+// Embed it in a component and implement Render.
+type BaseComponent struct {
+	mounted *MountedS
+}
+
+func (b *BaseComponent) setMounted(m *MountedS) {
+	b.mounted = m
+}
+
+func (b *BaseComponent) Mount()   {}
+func (b *BaseComponent) Update()  {}
+func (b *BaseComponent) Unmount() {}
+
+// Mounted returns the runtime the component was mounted with, or nil.
+func (b *BaseComponent) Mounted() *MountedS {
+	return b.mounted
+}
+
+// SetState runs fn, which should change the component's fields, and schedules a re-render.
+func (b *BaseComponent) SetState(fn func()) {
+	if fn != nil {
+		fn()
+	}
+	b.Invalidate()
+}
+
+// Invalidate schedules a re-render without changing anything.
+func (b *BaseComponent) Invalidate() {
+	if b.mounted != nil {
+		b.mounted.Invalidate()
+	}
+}
+
+////
+////
+////
+
+// MountedS is a component mounted into a container element.
+type MountedS struct {
+	component ComponentI
+	vdom      *VDomS
+
+	renderMutex sync.Mutex
+
+	mutex     sync.Mutex
+	dirty     bool
+	scheduled bool
+	unmounted bool
+
+	listeners *ListenerGroupS
+}
+
+// MountComponent renders c into container and then calls its Mount hook.
+// The container does not have to be in the document, so Mount cannot count
+// on the elements being laid out.
+func MountComponent(container ElementI, c ComponentI) *MountedS {
+	ret := &MountedS{
+		component: c,
+		vdom:      NewVDom(container),
+		listeners: NewListenerGroup(),
+	}
+	if b, ok := c.(componentBaseI); ok {
+		b.setMounted(ret)
+	}
+
+	ret.renderMutex.Lock()
+	defer ret.renderMutex.Unlock()
+
+	ret.vdom.Render(c.Render())
+	c.Mount()
+	return ret
+}
+
+func (m *MountedS) Component() ComponentI {
+	return m.component
+}
+
+// Root returns the element the component rendered to.
+func (m *MountedS) Root() ElementI {
+	if current := m.vdom.Current(); current != nil {
+		return current.Element()
+	}
+	return nil
+}
+
+// Invalidate schedules a re-render on the next animation frame. Any number
+// of calls before that frame result in a single render.
+func (m *MountedS) Invalidate() {
+	m.mutex.Lock()
+	m.dirty = true
+	if m.scheduled || m.unmounted {
+		m.mutex.Unlock()
+		return
+	}
+	m.scheduled = true
+	m.mutex.Unlock()
+
+	Window.RequestAnimationFrame(func(float64) {
+		m.Flush()
+	})
+}
+
+// Flush performs a pending re-render right away.
+func (m *MountedS) Flush() {
+	m.renderMutex.Lock()
+	defer m.renderMutex.Unlock()
+
+	m.mutex.Lock()
+	dirty := m.dirty && !m.unmounted
+	m.dirty = false
+	m.scheduled = false
+	m.mutex.Unlock()
+
+	if !dirty {
+		return
+	}
+	m.vdom.Render(m.component.Render())
+	m.component.Update()
+}
+
+// Listen adds a listener to a target outside of the component's own
+// elements, such as Window or Doc. It is removed when the component unmounts.
+func (m *MountedS) Listen(target EventTargetI, typ string, listener func(EventI)) EventListenerI {
+	return m.listeners.Add(target, typ, listener)
+}
+
+// Listeners returns the group Listen adds to, for listeners added in other
+// ways that should also be removed when the component unmounts.
+func (m *MountedS) Listeners() *ListenerGroupS {
+	return m.listeners
+}
+
+// Unmount calls the Unmount hook, then removes the component's elements
+// along with every listener they and Listen registered.
+func (m *MountedS) Unmount() {
+	m.renderMutex.Lock()
+	defer m.renderMutex.Unlock()
+
+	m.mutex.Lock()
+	if m.unmounted {
+		m.mutex.Unlock()
+		return
+	}
+	m.unmounted = true
+	m.mutex.Unlock()
+
+	m.component.Unmount()
+
+	m.listeners.Release()
+	m.vdom.Render(nil)
+}
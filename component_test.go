@@ -0,0 +1,58 @@
+package dom
+
+import (
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+type testCounter struct {
+	BaseComponent
+	count  int
+	events []string
+}
+
+func (c *testCounter) Render() *VNode {
+	return NewVNode("span", NewVText(strconv.Itoa(c.count)))
+}
+
+func (c *testCounter) Mount()   { c.events = append(c.events, "mount") }
+func (c *testCounter) Update()  { c.events = append(c.events, "update") }
+func (c *testCounter) Unmount() { c.events = append(c.events, "unmount") }
+
+func TestComponentLifecycle(t *testing.T) {
+	container := Doc.CreateElement("div")
+	c := &testCounter{}
+
+	m := MountComponent(container, c)
+	if c.Mounted() != m {
+		t.Errorf("expected the component to know its runtime\n")
+	}
+	root := m.Root()
+	if len(container.ChildNodes()) != 1 || container.FirstChild() != root {
+		t.Fatalf("expected the root element in the container\n")
+	}
+
+	c.SetState(func() { c.count++ })
+	c.SetState(func() { c.count++ })
+	m.Flush()
+	m.Flush()
+
+	if m.Root() != root {
+		t.Errorf("expected the root element to be kept across renders\n")
+	}
+	if text := m.vdom.Current().Children[0].Text; text != "2" {
+		t.Errorf("expected: %s but found: %s\n", "2", text)
+	}
+
+	m.Unmount()
+	m.Unmount()
+	if len(container.ChildNodes()) != 0 {
+		t.Errorf("expected an empty container but found: %d children\n", len(container.ChildNodes()))
+	}
+
+	expected := []string{"mount", "update", "unmount"}
+	if !reflect.DeepEqual(expected, c.events) {
+		t.Errorf("expected: %+v but found: %+v\n", expected, c.events)
+	}
+}
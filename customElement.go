@@ -0,0 +1,262 @@
+package dom
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+/*
+Custom elements backed by Go types. The tag is registered with
+customElements.define and every element created from it, by Go, HTML or
+another framework, gets its own Go value:
+
+	type counterS struct {
+		BaseCustomElement
+		host  ElementI
+		label ElementI
+	}
+
+	func (c *counterS) AttributeChanged(name, oldValue, newValue string) {
+		c.label.SetTextContent(newValue)
+	}
+
+	err := DefineCustomElement("x-counter", []string{"count"}, func(host ElementI) CustomElementI {
+		c := &counterS{host: host, label: El("span")}
+		host.AttachShadow(ShadowRootMode_Open).AppendChild(c.label)
+		return c
+	})
+
+The constructor runs inside the element's JavaScript constructor, so like
+there it must not add attributes or children to the host itself. Build the
+contents in a shadow root, or in Connected.
+*/
+
+/*
+https://developer.mozilla.org/en-US/docs/Web/API/ShadowRoot/mode
+*/
+type ShadowRootMode string
+
+const (
+	ShadowRootMode_Open   ShadowRootMode = "open"
+	ShadowRootMode_Closed ShadowRootMode = "closed" // the root is only reachable through the ShadowRootI returned by AttachShadow
+)
+
+// https://developer.mozilla.org/en-US/docs/Web/API/ShadowRoot
+type ShadowRootI interface {
+	DocumentFragmentI
+
+	Host() ElementI       // https://developer.mozilla.org/en-US/docs/Web/API/ShadowRoot/host
+	Mode() ShadowRootMode // https://developer.mozilla.org/en-US/docs/Web/API/ShadowRoot/mode
+}
+
+type shadowRootS struct {
+	*documentFragmentS
+	host ElementI
+}
+
+var _ ShadowRootI = &shadowRootS{}
+
+func newShadowRoot(val ValueI, host ElementI) *shadowRootS {
+	ret := &shadowRootS{
+		documentFragmentS: NewDocumentFragment(val),
+		host:              host,
+	}
+	return ret
+}
+
+func (s *shadowRootS) Host() ElementI       { return s.host }
+func (s *shadowRootS) Mode() ShadowRootMode { return ShadowRootMode(s.Get("mode").String()) }
+
+func (s *shadowRootS) Append(children ...ElementI) DocumentFragmentI {
+	s.documentFragmentS.Append(children...)
+	return s
+}
+
+// AttachShadow attaches a shadow root and returns it. The shadow root is
+// released along with the element by Remove.
+func (e *elementS) AttachShadow(mode ShadowRootMode) ShadowRootI {
+	val := e.Call("attachShadow", map[string]any{"mode": string(mode)})
+	root := newShadowRoot(val, e)
+	e.mutex.Lock()
+	e.shadowRoot = root
+	e.mutex.Unlock()
+	nodeRegistry.add(e)
+	return root
+}
+
+// ShadowRoot returns the shadow root attached with AttachShadow, or an open
+// shadow root attached outside of Go. Returns nil if there is neither.
+func (e *elementS) ShadowRoot() ShadowRootI {
+	e.mutex.Lock()
+	root := e.shadowRoot
+	e.mutex.Unlock()
+	if root != nil {
+		return root
+	}
+
+	val := e.Get("shadowRoot")
+	if val.IsNull() || val.IsUndefined() {
+		return nil
+	}
+	root = newShadowRoot(val, e)
+	root.children = wrapChildNodes(val)
+
+	e.mutex.Lock()
+	if e.shadowRoot == nil {
+		e.shadowRoot = root
+	}
+	root = e.shadowRoot
+	e.mutex.Unlock()
+
+	// outside e.mutex, as registering writes to the node
+	nodeRegistry.add(e)
+	return root
+}
+
+////
+////
+////
+
+// CustomElementI is implemented by the Go type behind a custom element.
+// The callbacks run synchronously while the browser updates the element.
+// https://developer.mozilla.org/en-US/docs/Web/API/Web_components/Using_custom_elements#custom_element_lifecycle_callbacks
+type CustomElementI interface {
+	Connected()    // the element was added to the document
+	Disconnected() // the element was removed from the document
+
+	// AttributeChanged is called for the observed attributes passed to
+	// DefineCustomElement, including the ones present when the element is
+	// created. A missing attribute is reported as "".
+	AttributeChanged(name, oldValue, newValue string)
+}
+
+// BaseCustomElement provides no-op callbacks for types that only need some of them.
+type BaseCustomElement struct{}
+
+func (BaseCustomElement) Connected()                                       {}
+func (BaseCustomElement) Disconnected()                                    {}
+func (BaseCustomElement) AttributeChanged(name, oldValue, newValue string) {}
+
+// customElementsS holds the Go value of every custom element still alive in
+// JavaScript. Each element keeps its key in a private field and entries are
+// dropped by a FinalizationRegistry once the element is garbage collected.
+type customElementsS struct {
+	mutex     sync.Mutex
+	nextKey   int
+	instances map[int]CustomElementI
+}
+
+var customElements = customElementsS{instances: map[int]CustomElementI{}}
+
+func (s *customElementsS) add(c CustomElementI) int {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.nextKey++
+	s.instances[s.nextKey] = c
+	return s.nextKey
+}
+
+func (s *customElementsS) get(key int) CustomElementI {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.instances[key]
+}
+
+func (s *customElementsS) forget(key int) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	delete(s.instances, key)
+}
+
+// customElementClass is the body of a function taking customElementParams.
+// It returns a class that extends HTMLElement and forwards its lifecycle to
+// the Go functions passed as arguments.
+var customElementParams = []any{"observed", "created", "connected", "disconnected", "attributeChanged", "finalized"}
+
+const customElementClass = `
+const registry = new FinalizationRegistry(finalized);
+return class extends HTMLElement {
+	#key;
+	static get observedAttributes() { return observed; }
+	constructor() {
+		super();
+		this.#key = created(this);
+		registry.register(this, this.#key);
+	}
+	connectedCallback() { connected(this.#key); }
+	disconnectedCallback() { disconnected(this.#key); }
+	attributeChangedCallback(name, oldValue, newValue) { attributeChanged(this.#key, name, oldValue, newValue); }
+};`
+
+// validCustomElementName checks the rules browsers enforce on custom element
+// names, so a bad name is reported as an error instead of a JavaScript exception.
+// https://html.spec.whatwg.org/multipage/custom-elements.html#valid-custom-element-name
+func validCustomElementName(name string) error {
+	switch {
+	case name == "" || name[0] < 'a' || name[0] > 'z':
+		return fmt.Errorf("custom element name %q must start with a lowercase letter", name)
+	case !strings.Contains(name, "-"):
+		return fmt.Errorf("custom element name %q must contain a hyphen", name)
+	case strings.ToLower(name) != name:
+		return fmt.Errorf("custom element name %q must be lowercase", name)
+	}
+	switch name {
+	case "annotation-xml", "color-profile", "font-face", "font-face-src",
+		"font-face-uri", "font-face-format", "font-face-name", "missing-glyph":
+		return fmt.Errorf("custom element name %q is reserved", name)
+	}
+	return nil
+}
+
+// DefineCustomElement registers name as a custom element. constructor is
+// called once for every element created with the tag and returns the Go
+// value that receives its lifecycle callbacks. Changes to the observed
+// attributes are reported through AttributeChanged.
+// https://developer.mozilla.org/en-US/docs/Web/API/CustomElementRegistry/define
+func DefineCustomElement(name string, observedAttributes []string, constructor func(host ElementI) CustomElementI) error {
+	if err := validCustomElementName(name); err != nil {
+		return err
+	}
+	registry := Window.Underlying().Get("customElements")
+	if registry.Call("get", name).Truthy() {
+		return fmt.Errorf("custom element %q is already defined", name)
+	}
+
+	observed := make([]any, 0, len(observedAttributes))
+	for _, attr := range observedAttributes {
+		observed = append(observed, attr)
+	}
+
+	// the functions stay registered for as long as the page, like the definition itself
+	created := NewFuncForJavascript(func(this ValueI, args []ValueI) any {
+		return customElements.add(constructor(newNode(args[0])))
+	})
+	connected := NewFuncForJavascript(func(this ValueI, args []ValueI) any {
+		if c := customElements.get(args[0].Int()); c != nil {
+			c.Connected()
+		}
+		return nil
+	})
+	disconnected := NewFuncForJavascript(func(this ValueI, args []ValueI) any {
+		if c := customElements.get(args[0].Int()); c != nil {
+			c.Disconnected()
+		}
+		return nil
+	})
+	attributeChanged := NewFuncForJavascript(func(this ValueI, args []ValueI) any {
+		if c := customElements.get(args[0].Int()); c != nil {
+			c.AttributeChanged(args[1].String(), args[2].String(), args[3].String())
+		}
+		return nil
+	})
+	finalized := NewFuncForJavascript(func(this ValueI, args []ValueI) any {
+		customElements.forget(args[0].Int())
+		return nil
+	})
+
+	factory := Window.Underlying().Get("Function").New(append(customElementParams, customElementClass)...)
+	class := factory.Invoke(observed, created, connected, disconnected, attributeChanged, finalized)
+	registry.Call("define", name, class)
+	return nil
+}
@@ -0,0 +1,43 @@
+package dom
+
+import "testing"
+
+func TestValidCustomElementName(t *testing.T) {
+	tests := []struct {
+		name  string
+		valid bool
+	}{
+		{"x-counter", true},
+		{"my-widget-2", true},
+		{"counter", false},
+		{"X-counter", false},
+		{"x-Counter", false},
+		{"-counter", false},
+		{"font-face", false},
+		{"", false},
+	}
+	for _, test := range tests {
+		err := validCustomElementName(test.name)
+		if (err == nil) != test.valid {
+			t.Errorf("%q: expected valid: %v but found error: %v\n", test.name, test.valid, err)
+		}
+	}
+}
+
+func TestShadowRootRemovedWithHost(t *testing.T) {
+	host := Doc.CreateElement("div")
+	root := host.AttachShadow(ShadowRootMode_Open)
+	root.AppendChild(Doc.CreateElement("span"))
+
+	if len(host.ShadowRoot().ChildNodes()) != 1 {
+		t.Errorf("expected: 1 but found: %d\n", len(host.ShadowRoot().ChildNodes()))
+	}
+	if host.ShadowRoot().Host() != host {
+		t.Errorf("expected the shadow root's host to be the element\n")
+	}
+
+	host.Remove()
+	if len(root.ChildNodes()) != 0 {
+		t.Errorf("expected: 0 but found: %d\n", len(root.ChildNodes()))
+	}
+}
@@ -0,0 +1,324 @@
+package dom
+
+import (
+	"encoding"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// https://developer.mozilla.org/en-US/docs/Web/API/HTMLElement/dataset
+// Keys are the camelCase form of the attribute name, so data-row-id is "rowId".
+type DatasetI interface {
+	Get(key string) string
+	Has(key string) bool
+	Set(key, value string)
+	Delete(key string)
+	Keys() []string
+}
+
+func NewDataset(val ValueI) datasetS {
+	ret := datasetS{
+		ValueI: val,
+	}
+	return ret
+}
+
+type datasetS struct {
+	ValueI
+}
+
+var _ DatasetI = datasetS{}
+
+func (s datasetS) Get(key string) string {
+	return s.ValueI.Get(key).String()
+}
+
+func (s datasetS) Has(key string) bool {
+	return !s.ValueI.Get(key).IsUndefined()
+}
+
+func (s datasetS) Set(key, value string) {
+	s.ValueI.Set(key, value)
+}
+
+func (s datasetS) Delete(key string) {
+	s.ValueI.Delete(key)
+}
+
+func (s datasetS) Keys() []string {
+	var out []string
+	keys := Window.Underlying().Get("Object").Call("keys", s.ValueI)
+	for i := range keys.Length() {
+		out = append(out, keys.Index(i).String())
+	}
+	return out
+}
+
+////
+////
+////
+
+/*
+EncodeDataset writes the exported fields of the struct v (or pointer to it)
+to data-* attributes of e. The attribute name comes from the "data" tag,
+otherwise from the field name in kebab case, so RowID becomes data-row-id.
+
+	type rowMeta struct {
+		RowID   int
+		Created time.Time `data:"created"`
+		Note    string    `data:",omitempty"`
+		Scratch string    `data:"-"`
+	}
+
+Supported field types are strings, bools, ints, uints, floats,
+time.Time (RFC 3339), time.Duration and anything implementing
+encoding.TextMarshaler and encoding.TextUnmarshaler.
+*/
+func EncodeDataset(e ElementI, v any) error {
+	rv := reflect.Indirect(reflect.ValueOf(v))
+	if rv.Kind() != reflect.Struct {
+		return fmt.Errorf("dataset: expected a struct but got %T", v)
+	}
+
+	for _, f := range dataFields(rv.Type()) {
+		fv, err := rv.FieldByIndexErr(f.index)
+		if err != nil {
+			// inside a nil embedded struct pointer, so there is no value
+			continue
+		}
+		if f.omitEmpty && fv.IsZero() {
+			e.RemoveAttribute("data-" + f.name)
+			continue
+		}
+		s, err := formatValue(fv)
+		if err != nil {
+			return fmt.Errorf("dataset: field %s: %w", f.field, err)
+		}
+		e.SetAttribute("data-"+f.name, s)
+	}
+	return nil
+}
+
+// DecodeDataset reads data-* attributes of e into the struct pointed to by v.
+// Fields without a matching attribute are left untouched.
+func DecodeDataset(e ElementI, v any) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("dataset: expected a non-nil pointer to a struct but got %T", v)
+	}
+	rv = rv.Elem()
+
+	for _, f := range dataFields(rv.Type()) {
+		if !e.HasAttribute("data-" + f.name) {
+			continue
+		}
+		s := e.GetAttribute("data-" + f.name)
+		fv, err := settableField(rv, f.index)
+		if err != nil {
+			return fmt.Errorf("dataset: field %s: %w", f.field, err)
+		}
+		if err := parseValue(s, fv); err != nil {
+			return fmt.Errorf("dataset: field %s: %w", f.field, err)
+		}
+	}
+	return nil
+}
+
+// settableField returns the field of v at index, allocating the nil
+// embedded struct pointers on the way to it.
+func settableField(v reflect.Value, index []int) (reflect.Value, error) {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				if !v.CanSet() {
+					return reflect.Value{}, fmt.Errorf("cannot set embedded pointer to unexported %s", v.Type().Elem())
+				}
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v, nil
+}
+
+type dataFieldT struct {
+	field     string
+	name      string
+	index     []int
+	omitEmpty bool
+}
+
+// dataFields lists the exported fields of t with their data-* names.
+func dataFields(t reflect.Type) []dataFieldT {
+	var out []dataFieldT
+	for _, sf := range reflect.VisibleFields(t) {
+		if !sf.IsExported() || sf.Anonymous {
+			continue
+		}
+		tag := sf.Tag.Get("data")
+		if tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+		if name == "" {
+			name = kebabCase(sf.Name)
+		}
+		out = append(out, dataFieldT{
+			field:     sf.Name,
+			name:      name,
+			index:     sf.Index,
+			omitEmpty: hasTagOption(opts, "omitempty"),
+		})
+	}
+	return out
+}
+
+// kebabCase turns a Go identifier into an attribute name: RowID becomes row-id.
+func kebabCase(s string) string {
+	runes := []rune(s)
+	var b strings.Builder
+	for i, r := range runes {
+		if unicode.IsUpper(r) {
+			prevLower := i > 0 && !unicode.IsUpper(runes[i-1])
+			nextLower := i > 0 && i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if prevLower || nextLower {
+				b.WriteByte('-')
+			}
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+var (
+	timeType     = reflect.TypeOf(time.Time{})
+	durationType = reflect.TypeOf(time.Duration(0))
+)
+
+// formatValue converts a Go value to the string stored in an attribute or form field.
+func formatValue(v reflect.Value) (string, error) {
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return "", nil
+		}
+		v = v.Elem()
+	}
+
+	switch v.Type() {
+	case timeType:
+		return v.Interface().(time.Time).Format(time.RFC3339Nano), nil
+	case durationType:
+		return v.Interface().(time.Duration).String(), nil
+	}
+	if m, ok := textMarshaler(v); ok {
+		b, err := m.MarshalText()
+		return string(b), err
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		return v.String(), nil
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(v.Uint(), 10), nil
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'g', -1, v.Type().Bits()), nil
+	default:
+		return "", fmt.Errorf("unsupported type %s", v.Type())
+	}
+}
+
+// textMarshaler returns v as an encoding.TextMarshaler, also when only a
+// pointer to it is one, as parseValue does for encoding.TextUnmarshaler.
+func textMarshaler(v reflect.Value) (encoding.TextMarshaler, bool) {
+	if m, ok := v.Interface().(encoding.TextMarshaler); ok {
+		return m, true
+	}
+	if !v.CanAddr() {
+		// a copy, so methods with a pointer receiver can be called
+		p := reflect.New(v.Type())
+		p.Elem().Set(v)
+		v = p.Elem()
+	}
+	m, ok := v.Addr().Interface().(encoding.TextMarshaler)
+	return m, ok
+}
+
+// parseValue converts s and stores it in v, which must be settable.
+func parseValue(s string, v reflect.Value) error {
+	if v.Kind() == reflect.Ptr {
+		if s == "" {
+			v.Set(reflect.Zero(v.Type()))
+			return nil
+		}
+		if v.IsNil() {
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+		v = v.Elem()
+	}
+
+	switch v.Type() {
+	case timeType:
+		if s == "" {
+			v.Set(reflect.Zero(timeType))
+			return nil
+		}
+		t, err := time.Parse(time.RFC3339Nano, s)
+		if err != nil {
+			return err
+		}
+		v.Set(reflect.ValueOf(t))
+		return nil
+	case durationType:
+		d, err := time.ParseDuration(s)
+		if err != nil {
+			return err
+		}
+		v.SetInt(int64(d))
+		return nil
+	}
+	if u, ok := v.Addr().Interface().(encoding.TextUnmarshaler); ok {
+		return u.UnmarshalText([]byte(s))
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(s)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(s, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		u, err := strconv.ParseUint(s, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetUint(u)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(s, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetFloat(f)
+	default:
+		return fmt.Errorf("unsupported type %s", v.Type())
+	}
+	return nil
+}
@@ -0,0 +1,127 @@
+package dom
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestKebabCase(t *testing.T) {
+	cases := map[string]string{
+		"Name":       "name",
+		"RowID":      "row-id",
+		"HTTPServer": "http-server",
+		"CreatedAt":  "created-at",
+		"Col2":       "col2",
+	}
+	for in, expected := range cases {
+		if found := kebabCase(in); found != expected {
+			t.Errorf("expected: %s but found: %s\n", expected, found)
+		}
+	}
+}
+
+func TestDataFields(t *testing.T) {
+	type meta struct {
+		RowID   int
+		Created time.Time `data:"created"`
+		Note    string    `data:",omitempty"`
+		Tags    string    `data:"tags,required,omitempty"`
+		Scratch string    `data:"-"`
+		hidden  string
+	}
+	var names, omitted []string
+	for _, f := range dataFields(reflect.TypeOf(meta{})) {
+		names = append(names, f.name)
+		if f.omitEmpty {
+			omitted = append(omitted, f.name)
+		}
+	}
+	expected := []string{"row-id", "created", "note", "tags"}
+	if !reflect.DeepEqual(expected, names) {
+		t.Errorf("expected: %+v but found: %+v\n", expected, names)
+	}
+	// omitempty is found among other options
+	if !reflect.DeepEqual([]string{"note", "tags"}, omitted) {
+		t.Errorf("expected: %+v but found: %+v\n", []string{"note", "tags"}, omitted)
+	}
+}
+
+func TestFormatParseValueRoundTrip(t *testing.T) {
+	values := []any{
+		"text",
+		true,
+		int64(-42),
+		uint8(200),
+		3.25,
+		float32(1.5),
+		time.Date(2024, 5, 6, 7, 8, 9, 10, time.UTC),
+		90 * time.Second,
+		pointText{X: 3, Y: -4},
+	}
+	for _, in := range values {
+		s, err := formatValue(reflect.ValueOf(in))
+		if err != nil {
+			t.Errorf("unexpected error: %+v\n", err)
+			continue
+		}
+		out := reflect.New(reflect.TypeOf(in))
+		if err := parseValue(s, out.Elem()); err != nil {
+			t.Errorf("unexpected error: %+v\n", err)
+			continue
+		}
+		if !reflect.DeepEqual(in, out.Elem().Interface()) {
+			t.Errorf("expected: %+v but found: %+v\n", in, out.Elem().Interface())
+		}
+	}
+}
+
+func TestParseValueError(t *testing.T) {
+	var i int
+	if err := parseValue("abc", reflect.ValueOf(&i).Elem()); err == nil {
+		t.Errorf("expected an error\n")
+	}
+}
+
+// pointText is encoded as text through methods with pointer receivers.
+type pointText struct{ X, Y int }
+
+func (p *pointText) MarshalText() ([]byte, error) {
+	return []byte(fmt.Sprintf("%d,%d", p.X, p.Y)), nil
+}
+
+func (p *pointText) UnmarshalText(b []byte) error {
+	_, err := fmt.Sscanf(string(b), "%d,%d", &p.X, &p.Y)
+	return err
+}
+
+func TestDatasetEmbeddedPointer(t *testing.T) {
+	type Audit struct {
+		Owner string
+	}
+	type row struct {
+		RowID int
+		*Audit
+	}
+	val := fakeNode("tr", nil)
+	e := newNode(val)
+
+	// the fields of a nil embedded struct are left out
+	if err := EncodeDataset(e, row{RowID: 7}); err != nil {
+		t.Fatalf("unexpected error: %v\n", err)
+	}
+	if _, ok := val.props["data-owner"]; ok || val.props["data-row-id"] != "7" {
+		t.Errorf("expected: only data-row-id but found: %+v\n", val.props)
+	}
+
+	// and allocated when read back
+	e.SetAttribute("data-owner", "ann")
+	var found row
+	if err := DecodeDataset(e, &found); err != nil {
+		t.Fatalf("unexpected error: %v\n", err)
+	}
+	if found.RowID != 7 || found.Audit == nil || found.Owner != "ann" {
+		t.Errorf("expected: row 7 owned by ann but found: %+v\n", found)
+	}
+}
@@ -0,0 +1,54 @@
+package dom
+
+/*
+Event delegation: one listener on a container handles an event for every
+descendant matching a selector, including ones added later, instead of a
+listener per row or cell:
+
+	table.On("click", "td.editable", func(ev EventI, cell ElementI) {
+		startEditing(cell)
+	})
+*/
+
+// On adds a delegated listener for events of type typ coming from
+// descendants of e that match selector. handler gets the closest match
+// between the event's target and e. The match is found while the event is
+// dispatched, and handler is called in a new goroutine like other
+// listeners. The listener is removed with e.
+func (e *elementS) On(typ, selector string, handler func(ev EventI, matched ElementI)) EventListenerI {
+	return e.OnWithOptions(typ, selector, ListenerOptionsT{}, handler)
+}
+
+// OnWithOptions is On with ListenerOptionsT. PreventDefault and
+// StopPropagation only apply to events that matched selector.
+func (e *elementS) OnWithOptions(typ, selector string, opts ListenerOptionsT, handler func(ev EventI, matched ElementI)) EventListenerI {
+	listenerOpts := ListenerOptionsT{Capture: opts.Capture, Passive: opts.Passive, Sync: true}
+	return e.AddEventListenerWithOptions(typ, listenerOpts, func(ev EventI) {
+		matched := delegateMatch(ev.Underlying().Get("target"), e.ValueI, selector)
+		if matched == nil {
+			return
+		}
+		if opts.PreventDefault {
+			ev.PreventDefault()
+		}
+		if opts.StopPropagation {
+			ev.StopPropagation()
+		}
+		opts.deliver(func() { handler(ev, matched) })
+	})
+}
+
+// delegateMatch walks from target up to, but not including, container and
+// returns the first element matching selector. Elements are wrapped
+// without changing them.
+func delegateMatch(target, container ValueI, selector string) ElementI {
+	for node := target; !node.IsNull() && !node.IsUndefined(); node = node.Get("parentNode") {
+		if node.Equal(container) {
+			return nil
+		}
+		if node.Get("nodeType").Int() == NodeType_Element && node.Call("matches", selector).Bool() {
+			return observedNode(node)
+		}
+	}
+	return nil
+}
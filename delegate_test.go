@@ -0,0 +1,66 @@
+package dom
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDelegateMatch(t *testing.T) {
+	span := fakeNode("span", nil)
+	cell := fakeNode("td", map[string]any{"className": "cell editable"}, span)
+	row := fakeNode("tr", nil, cell)
+	table := fakeNode("table", nil, row)
+
+	tests := []struct {
+		selector string
+		expected ValueI
+	}{
+		{"td.editable", cell},
+		{"td", cell},
+		{"tr", row},
+		{"td.locked", nil},
+		{"table", nil}, // the container itself is never matched
+	}
+	for _, test := range tests {
+		var found ValueI
+		if matched := delegateMatch(span, table, test.selector); matched != nil {
+			found = matched.Underlying()
+		}
+		if found != test.expected {
+			t.Errorf("%s: expected: %+v but found: %+v\n", test.selector, test.expected, found)
+		}
+	}
+	if _, ok := cell.props["id"]; ok {
+		t.Errorf("expected: no id but found: %+v\n", cell.props["id"])
+	}
+}
+
+func TestDelegatedListener(t *testing.T) {
+	span := fakeNode("span", nil)
+	cell := fakeNode("td", map[string]any{"className": "editable"}, span)
+	plain := fakeNode("td", nil)
+	tableVal := fakeNode("table", nil, fakeNode("tr", nil, cell, plain))
+	table := newNode(tableVal)
+
+	var matched []ValueI
+	opts := ListenerOptionsT{Sync: true, PreventDefault: true}
+	table.OnWithOptions("click", "td.editable", opts, func(ev EventI, m ElementI) {
+		matched = append(matched, m.Underlying())
+	})
+
+	hit := tableVal.dispatch("click", span)
+	missed := tableVal.dispatch("click", plain)
+	if !reflect.DeepEqual([]ValueI{cell}, matched) {
+		t.Errorf("expected: %+v but found: %+v\n", []ValueI{cell}, matched)
+	}
+	// only events that matched are changed
+	if !reflect.DeepEqual([]string{"preventDefault"}, hit.calls) || len(missed.calls) != 0 {
+		t.Errorf("expected: only the matched event prevented but found: %+v and %+v\n", hit.calls, missed.calls)
+	}
+
+	// delegated listeners go with the container
+	table.Remove()
+	if len(tableVal.listeners) != 0 {
+		t.Errorf("expected: no listeners but found: %+v\n", tableVal.listeners)
+	}
+}
@@ -0,0 +1,68 @@
+package dom
+
+import "sync"
+
+/*
+The dispatcher runs DOM work one function at a time on a single goroutine.
+Listeners normally run in their own goroutines, so two handlers touching
+the same state race. Handlers delivered through the dispatcher, and any
+other goroutine handing its work to Do or DoSync, never run at the same
+time:
+
+	button.AddEventListenerWithOptions("click", ListenerOptionsT{Dispatch: true}, func(ev EventI) {
+		count++
+		label.SetTextContent(strconv.Itoa(count))
+	})
+
+	go func() {
+		rows := fetchRows()
+		Do(func() { table.AppendChildren(rows...) })
+	}()
+
+Work runs in the order it was queued. Long running work holds up everything
+queued after it, so fetch data or compute outside and only hand the DOM
+changes to the dispatcher.
+*/
+
+var dispatcher struct {
+	once  sync.Once
+	queue *deliveryQueueS[func()]
+}
+
+func dispatchQueue() *deliveryQueueS[func()] {
+	dispatcher.once.Do(func() {
+		dispatcher.queue = newDeliveryQueue(func(fn func()) { fn() }, nil)
+	})
+	return dispatcher.queue
+}
+
+// Do queues fn to run on the dispatcher goroutine and returns without
+// waiting for it.
+func Do(fn func()) {
+	dispatchQueue().push(fn)
+}
+
+// DoSync runs fn on the dispatcher goroutine and waits for it to return.
+// It must not be called from work already running on the dispatcher, which
+// would wait for itself forever; call fn directly there.
+func DoSync(fn func()) {
+	done := make(chan struct{})
+	Do(func() {
+		defer close(done)
+		fn()
+	})
+	<-done
+}
+
+// deliver calls fn the way opts asks for: during dispatch, on the
+// dispatcher, or in a new goroutine.
+func (opts ListenerOptionsT) deliver(fn func()) {
+	switch {
+	case opts.Sync:
+		fn()
+	case opts.Dispatch:
+		Do(fn)
+	default:
+		go fn()
+	}
+}
@@ -0,0 +1,67 @@
+package dom
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+)
+
+func TestDoRunsInOrder(t *testing.T) {
+	var got []int
+	for i := range 100 {
+		Do(func() { got = append(got, i) })
+	}
+	// everything queued before DoSync has run once it returns
+	DoSync(func() {})
+
+	expected := make([]int, 100)
+	for i := range expected {
+		expected[i] = i
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected: %+v but found: %+v\n", expected, got)
+	}
+}
+
+func TestDispatchedListenersDoNotOverlap(t *testing.T) {
+	opts := ListenerOptionsT{Dispatch: true}
+	count := 0
+	var wg sync.WaitGroup
+	for range 50 {
+		wg.Add(1)
+		go opts.deliver(func() {
+			defer wg.Done()
+			count++
+		})
+	}
+	wg.Wait()
+
+	DoSync(func() {
+		if count != 50 {
+			t.Errorf("expected: %+v but found: %+v\n", 50, count)
+		}
+	})
+}
+
+func TestConcurrentElementBookkeeping(t *testing.T) {
+	parent := NewElement(valueS{})
+	var wg sync.WaitGroup
+	for range 20 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			child := NewElement(valueS{})
+			parent.AppendChild(child)
+			listener := parent.AddEventListener("click", false, func(EventI) {})
+			parent.ChildNodes()
+			parent.RemoveEventListener(listener)
+			parent.RemoveChild(child)
+		}()
+	}
+	wg.Wait()
+
+	if len(parent.ChildNodes()) != 0 {
+		t.Errorf("expected: %+v but found: %+v\n", 0, len(parent.ChildNodes()))
+	}
+	parent.Remove()
+}
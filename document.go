@@ -10,13 +10,21 @@ type DocumentI interface {
 
 	DocumentURI() string                           // https://developer.mozilla.org/en-US/docs/Web/API/Document/documentURI
 	CreateElement(name string) ElementI            // https://developer.mozilla.org/en-US/docs/Web/API/Document/createElement
+	CreateTextNode(data string) TextNodeI          // https://developer.mozilla.org/en-US/docs/Web/API/Document/createTextNode
+	CreateComment(data string) CommentI            // https://developer.mozilla.org/en-US/docs/Web/API/Document/createComment
+	CreateDocumentFragment() DocumentFragmentI     // https://developer.mozilla.org/en-US/docs/Web/API/Document/createDocumentFragment
 	ElementFromPoint(x, y int) ElementI            // https://developer.mozilla.org/en-US/docs/Web/API/Document/elementFromPoint
+	ElementsFromPoint(x, y int) []ElementI         // https://developer.mozilla.org/en-US/docs/Web/API/Document/elementsFromPoint
 	GetElementsByClassName(name string) []ElementI // https://developer.mozilla.org/en-US/docs/Web/API/Document/getElementsByClassName
 	GetElementsByTagName(name string) []ElementI   // https://developer.mozilla.org/en-US/docs/Web/API/Document/getElementsByTagName
 	GetElementByID(id string) ElementI             // https://developer.mozilla.org/en-US/docs/Web/API/Document/getElementById
 	QuerySelector(sel string) ElementI             // https://developer.mozilla.org/en-US/docs/Web/API/Document/querySelector
 	QuerySelectorAll(sel string) []ElementI        // https://developer.mozilla.org/en-US/docs/Web/API/Document/querySelectorAll
 
+	// ids given to elements created or wrapped for this document
+	IDGenerator() IDGeneratorI
+	SetIDGenerator(IDGeneratorI) // nil restores the default
+
 	// HTMLDocument
 	ActiveElement() ElementI // https://developer.mozilla.org/en-US/docs/Web/API/Document/activeElement
 	Body() ElementI          // https://developer.mozilla.org/en-US/docs/Web/API/Document/body
@@ -40,7 +48,7 @@ var _ DocumentI = &documentS{}
 func NewDocument(val ValueI) *documentS {
 	ret := &documentS{
 		ValueI:   val,
-		ElementI: NewElement(val),
+		ElementI: newNode(val), // a document has no id to give
 	}
 	return ret
 }
@@ -54,13 +62,35 @@ func (d *documentS) DocumentURI() string {
 }
 
 func (d documentS) CreateElement(name string) ElementI {
-	return NewElement(d.Call("createElement", name))
+	return newElementWithID(d.Call("createElement", name), d.IDGenerator().NextID())
+}
+
+func (d documentS) CreateTextNode(data string) TextNodeI {
+	return NewTextNode(d.Call("createTextNode", data))
+}
+
+func (d documentS) CreateComment(data string) CommentI {
+	return NewComment(d.Call("createComment", data))
+}
+
+func (d documentS) CreateDocumentFragment() DocumentFragmentI {
+	return NewDocumentFragment(d.Call("createDocumentFragment"))
 }
 
 func (d documentS) ElementFromPoint(x, y int) ElementI {
 	return NewElement(d.Call("elementFromPoint", x, y))
 }
 
+// ElementsFromPoint returns the elements at the point, topmost first. They
+// are wrapped without being given ids.
+func (d documentS) ElementsFromPoint(x, y int) []ElementI {
+	var out []ElementI
+	for _, val := range arrayToObjects(d.Call("elementsFromPoint", x, y)) {
+		out = append(out, observedNode(val))
+	}
+	return out
+}
+
 func (d documentS) GetElementsByClassName(name string) []ElementI {
 	return d.ElementI.GetElementsByClassName(name)
 }
@@ -89,6 +119,10 @@ func (s *documentS) AddEventListener(typ string, useCapture bool, listener func(
 	return s.ValueI.AddEventListener(typ, useCapture, listener)
 }
 
+func (s *documentS) AddEventListenerWithOptions(typ string, opts ListenerOptionsT, listener func(EventI)) EventListenerI {
+	return s.ValueI.AddEventListenerWithOptions(typ, opts, listener)
+}
+
 func (s *documentS) RemoveEventListener(listener EventListenerI) {
 	s.ValueI.RemoveEventListener(listener)
 }
@@ -102,7 +136,8 @@ func (s *documentS) DispatchEvent(event EventI) bool {
 ////
 
 func (s *documentS) ActiveElement() ElementI {
-	return NewElement(s.Get("activeElement"))
+	// wrapped without changing its id, as it usually comes from outside of Go
+	return observedNode(s.Get("activeElement"))
 }
 
 func (s *documentS) Body() ElementI {
@@ -0,0 +1,339 @@
+package dom
+
+import (
+	"slices"
+	"sync"
+)
+
+/*
+Drag and drop. The browser only lets the page accept a drop when dragover
+is canceled while it is dispatched, and only lets it read the dragged data
+during dragstart and drop, so the helpers here do that work synchronously
+and hand everything else to Go callbacks in a new goroutine:
+
+	MakeDraggable(card, DropEffect_Move, func(dt DataTransferI) {
+		dt.SetData("text/plain", card.ID())
+	})
+
+	zone := NewDropZone(column, DropZoneOptionsT{
+		Accept:     []string{"text/plain"},
+		DropEffect: DropEffect_Move,
+		HoverClass: "drag-over",
+		OnDrop: func(ev DragEventI, data DropDataT) {
+			moveCard(data.Text(), column)
+		},
+	})
+*/
+
+/*
+https://developer.mozilla.org/en-US/docs/Web/API/DataTransfer/dropEffect
+https://developer.mozilla.org/en-US/docs/Web/API/DataTransfer/effectAllowed
+*/
+type DropEffect string
+
+const (
+	DropEffect_None DropEffect = "none"
+	DropEffect_Copy DropEffect = "copy"
+	DropEffect_Move DropEffect = "move"
+	DropEffect_Link DropEffect = "link"
+
+	// only for EffectAllowed
+	DropEffect_CopyMove      DropEffect = "copyMove"
+	DropEffect_CopyLink      DropEffect = "copyLink"
+	DropEffect_LinkMove      DropEffect = "linkMove"
+	DropEffect_All           DropEffect = "all"
+	DropEffect_Uninitialized DropEffect = "uninitialized"
+)
+
+// DataTransferFormat_Files is listed in Types when files are dragged.
+const DataTransferFormat_Files = "Files"
+
+// https://developer.mozilla.org/en-US/docs/Web/API/DataTransfer
+type DataTransferI interface {
+	Underlying() ValueI
+
+	SetData(format, data string)           // https://developer.mozilla.org/en-US/docs/Web/API/DataTransfer/setData
+	GetData(format string) string          // https://developer.mozilla.org/en-US/docs/Web/API/DataTransfer/getData
+	ClearData(format string)               // clears every format when format is "": https://developer.mozilla.org/en-US/docs/Web/API/DataTransfer/clearData
+	Types() []string                       // https://developer.mozilla.org/en-US/docs/Web/API/DataTransfer/types
+	Files() []FileI                        // https://developer.mozilla.org/en-US/docs/Web/API/DataTransfer/files
+	DropEffect() DropEffect                // https://developer.mozilla.org/en-US/docs/Web/API/DataTransfer/dropEffect
+	SetDropEffect(DropEffect)              // https://developer.mozilla.org/en-US/docs/Web/API/DataTransfer/dropEffect
+	EffectAllowed() DropEffect             // https://developer.mozilla.org/en-US/docs/Web/API/DataTransfer/effectAllowed
+	SetEffectAllowed(DropEffect)           // https://developer.mozilla.org/en-US/docs/Web/API/DataTransfer/effectAllowed
+	SetDragImage(image ElementI, x, y int) // https://developer.mozilla.org/en-US/docs/Web/API/DataTransfer/setDragImage
+}
+
+type dataTransferS struct {
+	ValueI
+}
+
+var _ DataTransferI = dataTransferS{}
+
+func NewDataTransfer(val ValueI) dataTransferS {
+	return dataTransferS{ValueI: val}
+}
+
+func (d dataTransferS) Underlying() ValueI { return d.ValueI }
+
+func (d dataTransferS) SetData(format, data string)  { d.Call("setData", format, data) }
+func (d dataTransferS) GetData(format string) string { return d.Call("getData", format).String() }
+
+func (d dataTransferS) ClearData(format string) {
+	if format == "" {
+		d.Call("clearData")
+		return
+	}
+	d.Call("clearData", format)
+}
+
+func (d dataTransferS) Types() []string {
+	var out []string
+	for _, val := range nodeListToObjects(d.Get("types")) {
+		out = append(out, val.String())
+	}
+	return out
+}
+
+func (d dataTransferS) Files() []FileI {
+	return fileListToFiles(d.Get("files"))
+}
+
+func (d dataTransferS) DropEffect() DropEffect        { return DropEffect(d.Get("dropEffect").String()) }
+func (d dataTransferS) SetDropEffect(v DropEffect)    { d.Set("dropEffect", string(v)) }
+func (d dataTransferS) EffectAllowed() DropEffect     { return DropEffect(d.Get("effectAllowed").String()) }
+func (d dataTransferS) SetEffectAllowed(v DropEffect) { d.Set("effectAllowed", string(v)) }
+
+func (d dataTransferS) SetDragImage(image ElementI, x, y int) {
+	d.Call("setDragImage", image.Underlying(), x, y)
+}
+
+////
+////
+////
+
+// https://developer.mozilla.org/en-US/docs/Web/API/DragEvent
+type DragEventI interface {
+	EventI
+
+	DataTransfer() DataTransferI // https://developer.mozilla.org/en-US/docs/Web/API/DragEvent/dataTransfer
+	ClientX() float64            // https://developer.mozilla.org/en-US/docs/Web/API/MouseEvent/clientX
+	ClientY() float64            // https://developer.mozilla.org/en-US/docs/Web/API/MouseEvent/clientY
+}
+
+type dragEventS struct {
+	EventI
+}
+
+var _ DragEventI = dragEventS{}
+
+// AsDragEvent gives access to the drag properties of a drag event.
+func AsDragEvent(e EventI) DragEventI {
+	return dragEventS{EventI: e}
+}
+
+func (e dragEventS) DataTransfer() DataTransferI {
+	return NewDataTransfer(e.Underlying().Get("dataTransfer"))
+}
+
+func (e dragEventS) ClientX() float64 { return e.Underlying().Get("clientX").Float() }
+func (e dragEventS) ClientY() float64 { return e.Underlying().Get("clientY").Float() }
+
+////
+////
+////
+
+// MakeDraggable makes e draggable. onStart, which may be nil, runs during
+// dragstart, the only time data can be added to the DataTransfer, so it must
+// return quickly. The listener is removed with the element.
+func MakeDraggable(e ElementI, effectAllowed DropEffect, onStart func(dt DataTransferI)) EventListenerI {
+	e.SetDraggable(true)
+	opts := ListenerOptionsT{Sync: true}
+	return e.AddEventListenerWithOptions("dragstart", opts, func(ev EventI) {
+		dt := AsDragEvent(ev).DataTransfer()
+		if effectAllowed != "" {
+			dt.SetEffectAllowed(effectAllowed)
+		}
+		if onStart != nil {
+			onStart(dt)
+		}
+	})
+}
+
+// DropDataT is a copy of what was dropped. It is taken while the drop event
+// is dispatched, because the browser empties the DataTransfer afterwards.
+type DropDataT struct {
+	Types      []string
+	Data       map[string]string // by format, for every type but files
+	Files      []FileI
+	DropEffect DropEffect
+}
+
+// Text returns the dropped plain text.
+func (d DropDataT) Text() string {
+	return d.Data["text/plain"]
+}
+
+func newDropData(dt DataTransferI) DropDataT {
+	ret := DropDataT{
+		Types:      dt.Types(),
+		Data:       map[string]string{},
+		Files:      dt.Files(),
+		DropEffect: dt.DropEffect(),
+	}
+	for _, typ := range ret.Types {
+		if typ != DataTransferFormat_Files {
+			ret.Data[typ] = dt.GetData(typ)
+		}
+	}
+	return ret
+}
+
+// DropZoneOptionsT configures NewDropZone. Every callback may be nil.
+type DropZoneOptionsT struct {
+	Accept     []string   // formats that can be dropped, such as "Files" or "text/plain"; empty accepts anything
+	DropEffect DropEffect // the cursor shown while dragging over the zone, copy when empty
+	HoverClass string     // a class added to the zone while an accepted drag is over it
+
+	OnEnter func(ev DragEventI) // an accepted drag entered the zone
+	OnOver  func(ev DragEventI) // an accepted drag moved over the zone, many times a second
+	OnLeave func(ev DragEventI) // the drag left the zone without dropping
+	OnDrop  func(ev DragEventI, data DropDataT)
+}
+
+// DropZoneS makes an element accept drops. Entering and leaving child
+// elements of the zone is not reported as leaving the zone.
+type DropZoneS struct {
+	ElementI
+
+	opts      DropZoneOptionsT
+	mutex     sync.Mutex
+	depth     int // how many of the zone's elements the drag is inside
+	listeners []EventListenerI
+}
+
+func NewDropZone(e ElementI, opts DropZoneOptionsT) *DropZoneS {
+	if opts.DropEffect == "" {
+		opts.DropEffect = DropEffect_Copy
+	}
+	ret := &DropZoneS{
+		ElementI: e,
+		opts:     opts,
+	}
+
+	syncOpts := ListenerOptionsT{Sync: true}
+	ret.listeners = []EventListenerI{
+		e.AddEventListenerWithOptions("dragenter", syncOpts, ret.onEnter),
+		e.AddEventListenerWithOptions("dragover", syncOpts, ret.onOver),
+		e.AddEventListenerWithOptions("dragleave", syncOpts, ret.onLeave),
+		e.AddEventListenerWithOptions("drop", syncOpts, ret.onDrop),
+	}
+	return ret
+}
+
+// accepts reports whether the dragged data has one of the accepted formats.
+func (z *DropZoneS) accepts(dt DataTransferI) bool {
+	if len(z.opts.Accept) == 0 {
+		return true
+	}
+	for _, typ := range dt.Types() {
+		if slices.Contains(z.opts.Accept, typ) {
+			return true
+		}
+	}
+	return false
+}
+
+// The listeners below run during dispatch, so they only do what the
+// browser needs then and leave the callbacks to a goroutine.
+
+func (z *DropZoneS) onEnter(ev EventI) {
+	dragEv := AsDragEvent(ev)
+	if !z.accepts(dragEv.DataTransfer()) {
+		return
+	}
+	ev.PreventDefault()
+
+	z.mutex.Lock()
+	z.depth++
+	entered := z.depth == 1
+	z.mutex.Unlock()
+
+	if entered {
+		if z.opts.HoverClass != "" {
+			z.Class().Add(z.opts.HoverClass)
+		}
+		if z.opts.OnEnter != nil {
+			go z.opts.OnEnter(dragEv)
+		}
+	}
+}
+
+func (z *DropZoneS) onOver(ev EventI) {
+	dragEv := AsDragEvent(ev)
+	dt := dragEv.DataTransfer()
+	if !z.accepts(dt) {
+		return
+	}
+	ev.PreventDefault()
+	dt.SetDropEffect(z.opts.DropEffect)
+
+	if z.opts.OnOver != nil {
+		go z.opts.OnOver(dragEv)
+	}
+}
+
+func (z *DropZoneS) onLeave(ev EventI) {
+	dragEv := AsDragEvent(ev)
+	if !z.accepts(dragEv.DataTransfer()) {
+		return
+	}
+	if z.leave() && z.opts.OnLeave != nil {
+		go z.opts.OnLeave(dragEv)
+	}
+}
+
+// leave counts the drag leaving one of the zone's elements and reports
+// whether it left the zone.
+func (z *DropZoneS) leave() bool {
+	z.mutex.Lock()
+	if z.depth > 0 {
+		z.depth--
+	}
+	left := z.depth == 0
+	z.mutex.Unlock()
+
+	if left && z.opts.HoverClass != "" {
+		z.Class().Remove(z.opts.HoverClass)
+	}
+	return left
+}
+
+func (z *DropZoneS) onDrop(ev EventI) {
+	dragEv := AsDragEvent(ev)
+	dt := dragEv.DataTransfer()
+	if !z.accepts(dt) {
+		return
+	}
+	// keep the browser from opening dropped files
+	ev.PreventDefault()
+
+	z.mutex.Lock()
+	z.depth = 1
+	z.mutex.Unlock()
+	z.leave()
+
+	if z.opts.OnDrop != nil {
+		data := newDropData(dt)
+		go z.opts.OnDrop(dragEv, data)
+	}
+}
+
+// Release removes the drop zone's listeners.
+func (z *DropZoneS) Release() {
+	for _, listener := range z.listeners {
+		z.RemoveEventListener(listener)
+	}
+	z.listeners = nil
+	z.leave()
+}
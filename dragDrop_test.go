@@ -0,0 +1,56 @@
+package dom
+
+import (
+	"reflect"
+	"testing"
+)
+
+// fakeDataTransferS holds dragged data in Go, as the simulated backend has none.
+type fakeDataTransferS struct {
+	DataTransferI
+	types []string
+	data  map[string]string
+}
+
+func (f fakeDataTransferS) Types() []string              { return f.types }
+func (f fakeDataTransferS) GetData(format string) string { return f.data[format] }
+func (f fakeDataTransferS) Files() []FileI               { return nil }
+func (f fakeDataTransferS) DropEffect() DropEffect       { return DropEffect_Move }
+
+func TestDropZoneAccepts(t *testing.T) {
+	text := fakeDataTransferS{types: []string{"text/plain"}}
+	files := fakeDataTransferS{types: []string{DataTransferFormat_Files}}
+
+	zone := NewDropZone(Doc.CreateElement("div"), DropZoneOptionsT{Accept: []string{DataTransferFormat_Files}})
+	if zone.accepts(text) {
+		t.Errorf("expected text not to be accepted\n")
+	}
+	if !zone.accepts(files) {
+		t.Errorf("expected files to be accepted\n")
+	}
+
+	anything := NewDropZone(Doc.CreateElement("div"), DropZoneOptionsT{})
+	if !anything.accepts(text) {
+		t.Errorf("expected anything to be accepted without Accept\n")
+	}
+}
+
+func TestNewDropData(t *testing.T) {
+	dt := fakeDataTransferS{
+		types: []string{"text/plain", "text/uri-list", DataTransferFormat_Files},
+		data:  map[string]string{"text/plain": "card-7", "text/uri-list": "https://example.com"},
+	}
+
+	expected := DropDataT{
+		Types:      dt.types,
+		Data:       map[string]string{"text/plain": "card-7", "text/uri-list": "https://example.com"},
+		DropEffect: DropEffect_Move,
+	}
+	found := newDropData(dt)
+	if !reflect.DeepEqual(expected, found) {
+		t.Errorf("expected: %+v but found: %+v\n", expected, found)
+	}
+	if found.Text() != "card-7" {
+		t.Errorf("expected: %s but found: %s\n", "card-7", found.Text())
+	}
+}
@@ -1,16 +1,26 @@
 package dom
 
 import (
-	"fmt"
+	"slices"
 	"sync"
+	"time"
 )
 
 type ElementI interface {
 	EventTargetI
 	RemoveAllEventListeners()
+	// delegated listeners for descendants matching a selector
+	On(typ, selector string, handler func(ev EventI, matched ElementI)) EventListenerI
+	OnWithOptions(typ, selector string, opts ListenerOptionsT, handler func(ev EventI, matched ElementI)) EventListenerI
 
 	Underlying() ValueI
 
+	// released by Remove, RemoveChildren, SetInnerHTML, SetTextContent and SetOuterHTML
+	// of the element or any ancestor, along with the element's listeners
+	OnRemove(fn func()) (cancel func())
+	SetTimeout(d time.Duration, fn func()) (cancel func())
+	SetInterval(d time.Duration, fn func()) (cancel func())
+
 	// node
 	Remove()
 	RemoveChildren()
@@ -28,6 +38,7 @@ type ElementI interface {
 	TextContent() string
 	SetTextContent(string)
 	AppendChild(ElementI) ElementI
+	AppendChildren(...ElementI) // appends all children in one DOM insertion through a DocumentFragment
 	NewChild(typ string) ElementI
 	Clone(deep bool) ElementI // https://developer.mozilla.org/en-US/docs/Web/API/Node/cloneNode
 	CompareDocumentPosition(ElementI) int
@@ -49,6 +60,7 @@ type ElementI interface {
 	TagName() string
 	GetAttribute(name string) string
 	GetBoundingClientRect() RectI
+	GetClientRects() []RectI // https://developer.mozilla.org/en-US/docs/Web/API/Element/getClientRects
 	GetElementsByClassName(string) []ElementI
 	GetElementsByTagName(string) []ElementI
 	HasAttribute(name string) bool // https://developer.mozilla.org/en-US/docs/Web/API/Element/hasAttribute
@@ -62,11 +74,14 @@ type ElementI interface {
 	SetInnerHTML(string)
 	OuterHTML() string
 	SetOuterHTML(string)
+	AttachShadow(mode ShadowRootMode) ShadowRootI // https://developer.mozilla.org/en-US/docs/Web/API/Element/attachShadow
+	ShadowRoot() ShadowRootI                      // https://developer.mozilla.org/en-US/docs/Web/API/Element/shadowRoot
 
 	// HTML Element
 	ContentEditable() string
 	SetContentEditable(string)
 	IsContentEditable() bool
+	Dataset() DatasetI // https://developer.mozilla.org/en-US/docs/Web/API/HTMLElement/dataset
 	Draggable() bool
 	SetDraggable(bool)
 	OffsetHeight() float64
@@ -80,60 +95,118 @@ type ElementI interface {
 	Blur()            // https://developer.mozilla.org/en-US/docs/Web/API/HTMLElement/blur
 	Click()           // https://developer.mozilla.org/en-US/docs/Web/API/HTMLElement/click
 	Focus()           // https://developer.mozilla.org/en-US/docs/Web/API/HTMLElement/focus
-}
-
-type idMakerT struct {
-	mutex     sync.Mutex
-	idCounter int
-}
-
-// Make a global ID maker that can dispense IDs
-var idMaker idMakerT
-
-func GetNextID() string {
-	idMaker.mutex.Lock()
-	defer idMaker.mutex.Unlock()
-
-	newID := fmt.Sprintf("id_%06d", idMaker.idCounter)
-	idMaker.idCounter++
-	return newID
+	TabIndex() int    // https://developer.mozilla.org/en-US/docs/Web/API/HTMLElement/tabIndex
+	SetTabIndex(int)  // https://developer.mozilla.org/en-US/docs/Web/API/HTMLElement/tabIndex
+
+	// accessibility
+	Role() AriaRole                       // https://developer.mozilla.org/en-US/docs/Web/Accessibility/ARIA/Roles
+	SetRole(AriaRole)                     // https://developer.mozilla.org/en-US/docs/Web/Accessibility/ARIA/Roles
+	AriaLabel() string                    // https://developer.mozilla.org/en-US/docs/Web/Accessibility/ARIA/Attributes/aria-label
+	SetAriaLabel(string)                  // https://developer.mozilla.org/en-US/docs/Web/Accessibility/ARIA/Attributes/aria-label
+	AriaLabelledBy() []string             // https://developer.mozilla.org/en-US/docs/Web/Accessibility/ARIA/Attributes/aria-labelledby
+	SetAriaLabelledBy(labels ...ElementI) // https://developer.mozilla.org/en-US/docs/Web/Accessibility/ARIA/Attributes/aria-labelledby
+	AriaDescribedBy() []string            // https://developer.mozilla.org/en-US/docs/Web/Accessibility/ARIA/Attributes/aria-describedby
+	SetAriaDescribedBy(desc ...ElementI)  // https://developer.mozilla.org/en-US/docs/Web/Accessibility/ARIA/Attributes/aria-describedby
+	AriaExpanded() bool                   // https://developer.mozilla.org/en-US/docs/Web/Accessibility/ARIA/Attributes/aria-expanded
+	SetAriaExpanded(bool)                 // https://developer.mozilla.org/en-US/docs/Web/Accessibility/ARIA/Attributes/aria-expanded
+	AriaSelected() bool                   // https://developer.mozilla.org/en-US/docs/Web/Accessibility/ARIA/Attributes/aria-selected
+	SetAriaSelected(bool)                 // https://developer.mozilla.org/en-US/docs/Web/Accessibility/ARIA/Attributes/aria-selected
+	AriaLive() AriaLive                   // https://developer.mozilla.org/en-US/docs/Web/Accessibility/ARIA/Attributes/aria-live
+	SetAriaLive(AriaLive)                 // https://developer.mozilla.org/en-US/docs/Web/Accessibility/ARIA/Attributes/aria-live
+
+	// animation
+	Animate(keyframes []KeyframeT, opts AnimationOptionsT) AnimationI // https://developer.mozilla.org/en-US/docs/Web/API/Element/animate
+	GetAnimations() []AnimationI                                      // https://developer.mozilla.org/en-US/docs/Web/API/Element/getAnimations
+
+	// scrolling
+	ClientHeight() float64                            // https://developer.mozilla.org/en-US/docs/Web/API/Element/clientHeight
+	ClientWidth() float64                             // https://developer.mozilla.org/en-US/docs/Web/API/Element/clientWidth
+	ClientTop() float64                               // https://developer.mozilla.org/en-US/docs/Web/API/Element/clientTop
+	ClientLeft() float64                              // https://developer.mozilla.org/en-US/docs/Web/API/Element/clientLeft
+	ScrollHeight() float64                            // https://developer.mozilla.org/en-US/docs/Web/API/Element/scrollHeight
+	ScrollWidth() float64                             // https://developer.mozilla.org/en-US/docs/Web/API/Element/scrollWidth
+	ScrollTop() float64                               // https://developer.mozilla.org/en-US/docs/Web/API/Element/scrollTop
+	SetScrollTop(float64)                             // https://developer.mozilla.org/en-US/docs/Web/API/Element/scrollTop
+	ScrollLeft() float64                              // https://developer.mozilla.org/en-US/docs/Web/API/Element/scrollLeft
+	SetScrollLeft(float64)                            // https://developer.mozilla.org/en-US/docs/Web/API/Element/scrollLeft
+	ScrollTo(x, y float64, behavior ScrollBehavior)   // https://developer.mozilla.org/en-US/docs/Web/API/Element/scrollTo
+	ScrollBy(dx, dy float64, behavior ScrollBehavior) // https://developer.mozilla.org/en-US/docs/Web/API/Element/scrollBy
+	ScrollIntoView(opts ScrollIntoViewOptionsT)       // https://developer.mozilla.org/en-US/docs/Web/API/Element/scrollIntoView
+	ScrollToBottom(behavior ScrollBehavior)
+	IsScrolledToBottom(threshold float64) bool
+	OnScroll(listener func(ScrollInfoT)) EventListenerI
+
+	// focus
+	OnFocusIn(listener func(FocusEventI)) EventListenerI  // https://developer.mozilla.org/en-US/docs/Web/API/Element/focusin_event
+	OnFocusOut(listener func(FocusEventI)) EventListenerI // https://developer.mozilla.org/en-US/docs/Web/API/Element/focusout_event
 }
 
 type elementS struct {
 	ValueI
+	registryKey int // the key this wrapper is registered under in nodeRegistry, 0 if it is not
+
+	// mutex guards the bookkeeping below, which listeners running in their
+	// own goroutines may change at the same time. It is never held while
+	// calling into JavaScript, which may call back into Go.
+	mutex          sync.Mutex
 	id             string
 	children       []ElementI
 	eventListeners map[string]EventListenerI
+	shadowRoot     *shadowRootS
+	cleanups       []cleanupT
+	nextCleanupID  int
 }
 
 var _ ElementI = &elementS{}
 
+// NewElement wraps val and gives it an id from the generator of Doc. When
+// the generator gives none, the element keeps the id it has.
 func NewElement(val ValueI) *elementS {
-	ret := &elementS{
-		ValueI:         val,
-		eventListeners: map[string]EventListenerI{},
-		id:             GetNextID(),
-		children:       []ElementI{},
-	}
+	return newElementWithID(val, GetNextID())
+}
 
+func newElementWithID(val ValueI, id string) *elementS {
+	ret := newNode(val)
+	if id == "" {
+		ret.id = val.Get("id").String()
+		return ret
+	}
+	ret.id = id
 	val.Set("id", ret.id)
 	return ret
 }
 
+// Remove detaches the element and releases what every wrapper of it and of
+// its descendants holds, including wrappers not tracked as children.
 func (s *elementS) Remove() {
-	for _, child := range s.children {
-		child.Remove()
-	}
-	s.children = []ElementI{}
-	s.RemoveAllEventListeners()
+	nodeRegistry.releaseInside(s.ValueI, true)
+	s.releaseTree()
 	s.Call("remove")
 }
 
+// RemoveChildren removes every child node, tracked or not, and releases what
+// their wrappers hold.
 func (n *elementS) RemoveChildren() {
-	for _, child := range n.children {
-		child.Remove()
+	n.releaseDescendants()
+	n.Set("textContent", "")
+}
+
+// releaseDescendants releases the wrappers below n before the browser drops
+// its children.
+func (n *elementS) releaseDescendants() {
+	nodeRegistry.releaseInside(n.ValueI, false)
+	for _, child := range n.takeChildren() {
+		releaseChild(child)
 	}
+}
+
+// takeChildren empties the tracked children and returns what they were.
+func (n *elementS) takeChildren() []ElementI {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+	children := n.children
 	n.children = []ElementI{}
+	return children
 }
 
 func (n *elementS) Underlying() ValueI {
@@ -174,11 +247,16 @@ func nodeListToElements(o ValueI) []ElementI {
 	return out
 }
 
+// ChildNodes returns a copy of the tracked children.
 func (n *elementS) ChildNodes() []ElementI {
-	return n.children
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+	return slices.Clone(n.children)
 }
 
 func (n *elementS) FirstChild() ElementI {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
 	if len(n.children) == 0 {
 		return nil
 	}
@@ -186,6 +264,8 @@ func (n *elementS) FirstChild() ElementI {
 }
 
 func (n *elementS) LastChild() ElementI {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
 	if len(n.children) == 0 {
 		return nil
 	}
@@ -193,7 +273,7 @@ func (n *elementS) LastChild() ElementI {
 }
 
 func (n *elementS) NextSibling() ElementI {
-	return NewElement(n.Get("nextSibling"))
+	return NewNode(n.Get("nextSibling"))
 }
 
 func (n *elementS) NodeName() string {
@@ -213,11 +293,11 @@ func (n *elementS) SetNodeValue(s string) {
 }
 
 func (n *elementS) ParentNode() ElementI {
-	return NewElement(n.Get("parentNode"))
+	return NewNode(n.Get("parentNode"))
 }
 
 func (n *elementS) PreviousSibling() ElementI {
-	return NewElement(n.Get("previousSibling"))
+	return NewNode(n.Get("previousSibling"))
 }
 
 func (n *elementS) TextContent() string {
@@ -225,23 +305,50 @@ func (n *elementS) TextContent() string {
 }
 
 func (n *elementS) SetTextContent(s string) {
+	n.releaseDescendants()
 	n.Set("textContent", s)
 }
 
 func (n *elementS) AppendChild(newChild ElementI) ElementI {
-	n.children = append(n.children, newChild)
+	inserted := insertedChildren(newChild)
+	n.mutex.Lock()
+	n.forgetChild(newChild)
+	n.children = append(n.children, inserted...)
+	n.mutex.Unlock()
 	n.Call("appendChild", newChild.Underlying())
 	return newChild
 }
 
+func (n *elementS) AppendChildren(children ...ElementI) {
+	frag := Doc.CreateDocumentFragment()
+	frag.Append(children...)
+	n.AppendChild(frag)
+}
+
+// childIndex returns the position of child in the tracked children, or -1.
+// n.mutex must be held.
+func (n *elementS) childIndex(child ElementI) int {
+	return slices.IndexFunc(n.children, func(c ElementI) bool { return c == child })
+}
+
+// forgetChild drops child from the tracked children. Inserting a node that
+// is already a child moves it, so the old position has to go first.
+// n.mutex must be held.
+func (n *elementS) forgetChild(child ElementI) {
+	if i := n.childIndex(child); i >= 0 {
+		n.children = slices.Delete(n.children, i, i+1)
+	}
+}
+
 func (n *elementS) NewChild(typ string) ElementI {
 	newElement := Doc.CreateElement(typ)
 	n.AppendChild(newElement)
 	return newElement
 }
 
+// Clone copies the node. A copy of a text or comment node is wrapped as one.
 func (n *elementS) Clone(deep bool) ElementI {
-	return NewElement(n.Call("cloneNode", deep))
+	return NewNode(n.Call("cloneNode", deep))
 }
 
 func (n *elementS) CompareDocumentPosition(other ElementI) int {
@@ -258,9 +365,18 @@ func (n *elementS) HasChildNodes() bool {
 
 func (n *elementS) InsertBefore(which ElementI, before ElementI) {
 	var o interface{}
+	inserted := insertedChildren(which)
+	n.mutex.Lock()
+	n.forgetChild(which)
+	idx := len(n.children)
 	if before != nil {
 		o = before.Underlying()
+		if i := n.childIndex(before); i >= 0 {
+			idx = i
+		}
 	}
+	n.children = slices.Insert(n.children, idx, inserted...)
+	n.mutex.Unlock()
 	n.Call("insertBefore", which.Underlying(), o)
 }
 
@@ -276,11 +392,27 @@ func (n *elementS) Normalize() {
 	n.Call("normalize")
 }
 
+// RemoveChild detaches other and releases what the wrappers of it and of
+// its descendants hold, as Remove does.
 func (n *elementS) RemoveChild(other ElementI) {
+	n.mutex.Lock()
+	n.forgetChild(other)
+	n.mutex.Unlock()
+	releaseDetachedChild(other)
 	n.Call("removeChild", other.Underlying())
 }
 
+// ReplaceChild puts newChild in the place of oldChild, which is released as
+// by RemoveChild.
 func (n *elementS) ReplaceChild(newChild, oldChild ElementI) {
+	inserted := insertedChildren(newChild)
+	n.mutex.Lock()
+	n.forgetChild(newChild)
+	if i := n.childIndex(oldChild); i >= 0 {
+		n.children = slices.Replace(n.children, i, i+1, inserted...)
+	}
+	n.mutex.Unlock()
+	releaseDetachedChild(oldChild)
 	n.Call("replaceChild", newChild.Underlying(), oldChild.Underlying())
 }
 
@@ -306,6 +438,14 @@ func (e *elementS) GetBoundingClientRect() RectI {
 	return NewRect(e.Call("getBoundingClientRect"))
 }
 
+func (e *elementS) GetClientRects() []RectI {
+	var out []RectI
+	for _, val := range nodeListToObjects(e.Call("getClientRects")) {
+		out = append(out, NewRect(val))
+	}
+	return out
+}
+
 func (e *elementS) PreviousElementSibling() ElementI {
 	return NewElement(e.Get("previousElementSibling"))
 }
@@ -329,14 +469,20 @@ func (e *elementS) Closest(s string) ElementI {
 }
 
 func (e *elementS) ID() string {
-	if e.id == "" {
-		e.id = e.Get("id").String()
-
+	e.mutex.Lock()
+	id := e.id
+	e.mutex.Unlock()
+	if id == "" {
+		// the element may have been given an id outside of Go
+		return e.Get("id").String()
 	}
-	return e.id
+	return id
 }
 
 func (e *elementS) SetID(s string) {
+	e.mutex.Lock()
+	e.id = s
+	e.mutex.Unlock()
 	e.Set("id", s)
 }
 
@@ -385,6 +531,7 @@ func (e *elementS) InnerHTML() string {
 }
 
 func (e *elementS) SetInnerHTML(s string) {
+	e.releaseDescendants()
 	e.Set("innerHTML", s)
 }
 
@@ -392,7 +539,11 @@ func (e *elementS) OuterHTML() string {
 	return e.Get("outerHTML").String()
 }
 
+// SetOuterHTML replaces the element, so what its wrappers and those of its
+// descendants hold is released as by Remove.
 func (e *elementS) SetOuterHTML(s string) {
+	nodeRegistry.releaseInside(e.ValueI, true)
+	e.releaseTree()
 	e.Set("outerHTML", s)
 }
 
@@ -400,19 +551,55 @@ func (e *elementS) SetOuterHTML(s string) {
 ////
 
 func (s *elementS) AddEventListener(typ string, useCapture bool, listener func(EventI)) EventListenerI {
-	ret := s.ValueI.AddEventListener(typ, useCapture, listener)
+	return s.AddEventListenerWithOptions(typ, ListenerOptionsT{Capture: useCapture}, listener)
+}
+
+func (s *elementS) AddEventListenerWithOptions(typ string, opts ListenerOptionsT, listener func(EventI)) EventListenerI {
+	ret := s.ValueI.AddEventListenerWithOptions(typ, opts, listener)
+	s.mutex.Lock()
 	s.eventListeners[ret.GetID()] = ret
+	s.mutex.Unlock()
+	nodeRegistry.add(s)
 	return ret
 }
 
+// RemoveEventListener removes a listener added through any wrapper of the
+// element's node. A listener no wrapper holds any more, such as one already
+// removed along with the element, is left alone, as its function has been
+// released.
 func (s *elementS) RemoveEventListener(listener EventListenerI) {
-	s.ValueI.RemoveEventListener(listener)
-	delete(s.eventListeners, listener.GetID())
+	held := s.forgetListener(listener.GetID())
+	if !held {
+		for _, w := range nodeRegistry.wrappersOf(s.ValueI) {
+			if w != s && w.forgetListener(listener.GetID()) {
+				held = true
+				break
+			}
+		}
+	}
+
+	if held {
+		s.ValueI.RemoveEventListener(listener)
+	}
+}
+
+// forgetListener drops the listener and reports whether the wrapper held it.
+func (s *elementS) forgetListener(id string) bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	_, held := s.eventListeners[id]
+	delete(s.eventListeners, id)
+	return held
 }
 
 func (s *elementS) RemoveAllEventListeners() {
-	for _, eventListener := range s.eventListeners {
-		s.RemoveEventListener(eventListener)
+	s.mutex.Lock()
+	listeners := s.eventListeners
+	s.eventListeners = map[string]EventListenerI{}
+	s.mutex.Unlock()
+
+	for _, eventListener := range listeners {
+		s.ValueI.RemoveEventListener(eventListener)
 	}
 }
 
@@ -435,6 +622,10 @@ func (e *elementS) IsContentEditable() bool {
 	return e.Get("isContentEditable").Bool()
 }
 
+func (e *elementS) Dataset() DatasetI {
+	return NewDataset(e.Get("dataset"))
+}
+
 func (e *elementS) Draggable() bool {
 	return e.Get("draggable").Bool()
 }
@@ -25,10 +25,31 @@ type EventTargetI interface {
 	// that wrapper has to be used.
 	// The listener is called in a new goroutine.
 	AddEventListener(typ string, useCapture bool, listener func(EventI)) EventListenerI
+	// AddEventListenerWithOptions is AddEventListener with ListenerOptionsT.
+	AddEventListenerWithOptions(typ string, opts ListenerOptionsT, listener func(EventI)) EventListenerI
 	RemoveEventListener(listener EventListenerI)
 	DispatchEvent(event EventI) bool
 }
 
+// ListenerOptionsT controls how an event is handled before the listener
+// gets it. By the time a listener's goroutine runs, the browser has finished
+// dispatching the event, so calling PreventDefault from it has no effect.
+// https://developer.mozilla.org/en-US/docs/Web/API/EventTarget/addEventListener#options
+type ListenerOptionsT struct {
+	Capture         bool
+	Passive         bool // promise never to call PreventDefault, which lets the browser scroll without waiting
+	PreventDefault  bool // call PreventDefault before the listener runs
+	StopPropagation bool // call StopPropagation before the listener runs
+	// Sync calls the listener during dispatch instead of in a new goroutine,
+	// so it can decide whether to call PreventDefault. The listener must
+	// return quickly and must not block, as the browser waits for it.
+	Sync bool
+	// Dispatch runs the listener on the dispatcher goroutine, one at a time
+	// with the other work given to Do, instead of in a new goroutine.
+	// Ignored when Sync is set.
+	Dispatch bool
+}
+
 // Type BasicEvent implements the Event interface and is embedded by
 // concrete eventS types.
 type eventS struct {
@@ -57,9 +78,7 @@ func (ev eventS) Cancelable() bool {
 }
 
 func (ev eventS) CurrentTarget() ElementI {
-	val := ev.Get("currentTarget")
-
-	return &elementS{ValueI: val}
+	return observedNode(ev.Get("currentTarget"))
 }
 
 func (ev eventS) DefaultPrevented() bool {
@@ -71,8 +90,7 @@ func (ev eventS) EventPhase() int {
 }
 
 func (ev eventS) Target() ElementI {
-	val := ev.Get("target")
-	return &elementS{ValueI: val}
+	return observedNode(ev.Get("target"))
 }
 
 func (ev eventS) Timestamp() time.Time {
@@ -126,7 +144,7 @@ var _ EventListenerI = EventTargetS{}
 func NewEventListener(fn FuncI, typ string, capture bool) EventTargetS {
 	ret := EventTargetS{
 		FuncI:   fn,
-		id:      GetNextID(),
+		id:      listenerIDs.NextID(),
 		typ:     typ,
 		capture: capture,
 	}
@@ -0,0 +1,232 @@
+package dom
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// fakeNodeS is a node that keeps its properties, children and listeners,
+// so code reading and writing the page can be tested without a browser.
+// Attributes are kept with the properties.
+type fakeNodeS struct {
+	valueS
+	tag       string
+	props     map[string]any
+	children  []*fakeNodeS
+	calls     []string                // the methods called, in order
+	listeners map[string]func(EventI) // by event type
+}
+
+func fakeNode(tag string, props map[string]any, children ...*fakeNodeS) *fakeNodeS {
+	if props == nil {
+		props = map[string]any{}
+	}
+	ret := &fakeNodeS{tag: tag, props: props, children: children, listeners: map[string]func(EventI){}}
+	for _, child := range children {
+		child.props["parentNode"] = ret
+	}
+	return ret
+}
+
+func (n *fakeNodeS) Get(p string) ValueI {
+	switch p {
+	case "nodeType":
+		if typ, ok := n.props["nodeType"]; ok {
+			return fakeScalarS{v: typ}
+		}
+		return fakeScalarS{v: NodeType_Element}
+	case "tagName":
+		return fakeScalarS{v: strings.ToUpper(n.tag)}
+	case "children", "childNodes":
+		return fakeListS{nodes: n.children}
+	case "firstElementChild":
+		if len(n.children) == 0 {
+			return fakeScalarS{}
+		}
+		return n.children[0]
+	case "elements":
+		return fakeListS{nodes: n.descendants(func(d *fakeNodeS) bool {
+			return d.tag == "input" || d.tag == "select" || d.tag == "textarea"
+		})}
+	case "options":
+		return fakeListS{nodes: n.descendants(func(d *fakeNodeS) bool { return d.tag == "option" })}
+	case "attributes":
+		// the properties with string values stand in for the attributes
+		var attrs []*fakeNodeS
+		for name, value := range n.props {
+			if value, ok := value.(string); ok {
+				attrs = append(attrs, fakeNode("", map[string]any{"name": name, "value": value}))
+			}
+		}
+		return fakeListS{nodes: attrs}
+	case "selectedOptions":
+		return fakeListS{nodes: n.descendants(func(d *fakeNodeS) bool {
+			return d.tag == "option" && d.props["selected"] == true
+		})}
+	}
+	switch v := n.props[p].(type) {
+	case *fakeNodeS:
+		return v
+	case []*fakeNodeS:
+		return fakeListS{nodes: v}
+	}
+	return fakeScalarS{v: n.props[p]}
+}
+
+func (n *fakeNodeS) Truthy() bool { return true }
+
+func (n *fakeNodeS) Equal(w ValueI) bool {
+	return w == ValueI(n)
+}
+
+func (n *fakeNodeS) Set(p string, x any) {
+	n.props[p] = x
+}
+
+// Call supports simple selectors, * or a tag optionally with a single
+// [type="..."], and the few other methods the tests need.
+func (n *fakeNodeS) Call(m string, args ...any) ValueI {
+	n.calls = append(n.calls, m)
+	switch m {
+	case "querySelectorAll":
+		return fakeListS{nodes: n.querySelectorAll(args[0].(string))}
+	case "querySelector":
+		if found := n.querySelectorAll(args[0].(string)); len(found) > 0 {
+			return found[0]
+		}
+		return fakeScalarS{}
+	case "matches":
+		tag, class, _ := strings.Cut(args[0].(string), ".")
+		classes, _ := n.props["className"].(string)
+		return fakeScalarS{v: n.tag == tag && (class == "" || slices.Contains(strings.Fields(classes), class))}
+	case "checkValidity":
+		return fakeScalarS{v: true}
+	case "hasChildNodes":
+		return fakeScalarS{v: len(n.children) > 0}
+	case "setAttribute":
+		n.props[args[0].(string)] = args[1]
+	case "removeAttribute":
+		delete(n.props, args[0].(string))
+	case "add":
+		// a classList, whose value is its tokens joined with spaces
+		value, _ := n.props["value"].(string)
+		n.props["value"] = strings.TrimSpace(value + " " + args[0].(string))
+	case "getAttribute":
+		// attributes are kept with the properties
+		return fakeScalarS{v: n.props[args[0].(string)]}
+	case "hasAttribute":
+		_, ok := n.props[args[0].(string)]
+		return fakeScalarS{v: ok}
+	case "elementsFromPoint":
+		// the children stand in for whatever is under the point
+		return fakeListS{nodes: n.children}
+	}
+	return fakeScalarS{}
+}
+
+func (n *fakeNodeS) AddEventListenerWithOptions(typ string, opts ListenerOptionsT, listener func(EventI)) EventListenerI {
+	n.listeners[typ] = listener
+	return NewEventListener(funcS{}, typ, opts.Capture)
+}
+
+func (n *fakeNodeS) RemoveEventListener(listener EventListenerI) {
+	n.calls = append(n.calls, "removeEventListener")
+	delete(n.listeners, listener.GetType())
+}
+
+// dispatch calls the listener for typ, if any, with an event coming from
+// target, and returns the event.
+func (n *fakeNodeS) dispatch(typ string, target *fakeNodeS) *fakeNodeS {
+	ev := fakeNode("", map[string]any{"target": target})
+	if listener := n.listeners[typ]; listener != nil {
+		listener(eventS{ValueI: ev})
+	}
+	return ev
+}
+
+func (n *fakeNodeS) querySelectorAll(selector string) []*fakeNodeS {
+	tag, typ, _ := strings.Cut(selector, "[")
+	typ = strings.TrimSuffix(strings.TrimPrefix(typ, `type="`), `"]`)
+	return n.descendants(func(d *fakeNodeS) bool {
+		return (tag == "*" || d.tag == tag) && (typ == "" || d.props["type"] == typ)
+	})
+}
+
+// count returns how many times method was called.
+func (n *fakeNodeS) count(method string) int {
+	count := 0
+	for _, call := range n.calls {
+		if call == method {
+			count++
+		}
+	}
+	return count
+}
+
+func (n *fakeNodeS) descendants(match func(*fakeNodeS) bool) []*fakeNodeS {
+	var out []*fakeNodeS
+	for _, child := range n.children {
+		if match(child) {
+			out = append(out, child)
+		}
+		out = append(out, child.descendants(match)...)
+	}
+	return out
+}
+
+// fakeScalarS is a property value of a fakeNodeS.
+type fakeScalarS struct {
+	valueS
+	v any
+}
+
+func (s fakeScalarS) IsNull() bool { return s.v == nil }
+
+func (s fakeScalarS) Type() Type {
+	switch s.v.(type) {
+	case nil:
+		return TypeUndefined
+	case bool:
+		return TypeBoolean
+	case int, float64:
+		return TypeNumber
+	default:
+		return TypeString
+	}
+}
+
+func (s fakeScalarS) Bool() bool   { return s.v == true }
+func (s fakeScalarS) Truthy() bool { return s.v != nil && s.v != false && s.v != "" && s.v != 0 }
+
+func (s fakeScalarS) Int() int {
+	i, _ := s.v.(int)
+	return i
+}
+
+func (s fakeScalarS) String() string {
+	if s.v == nil {
+		return ""
+	}
+	return fmt.Sprint(s.v)
+}
+
+// fakeListS is a NodeList of fakeNodeS.
+type fakeListS struct {
+	valueS
+	nodes []*fakeNodeS
+}
+
+func (l fakeListS) Get(p string) ValueI {
+	if p == "length" {
+		return fakeScalarS{v: len(l.nodes)}
+	}
+	return fakeScalarS{}
+}
+
+func (l fakeListS) Call(m string, args ...any) ValueI {
+	return l.nodes[args[0].(int)]
+}
+
+func (l fakeListS) Index(i int) ValueI { return l.nodes[i] }
+func (l fakeListS) Length() int        { return len(l.nodes) }
@@ -0,0 +1,72 @@
+package dom
+
+import (
+	"errors"
+	"time"
+)
+
+// FileI is a file chosen by the user, from a drop or a file input.
+// Reading the contents waits for the browser, so it must be done from a
+// goroutine, such as an event listener, and never from a Sync listener.
+// https://developer.mozilla.org/en-US/docs/Web/API/File
+type FileI interface {
+	Underlying() ValueI
+
+	Name() string            // https://developer.mozilla.org/en-US/docs/Web/API/File/name
+	Size() int               // https://developer.mozilla.org/en-US/docs/Web/API/Blob/size
+	Type() string            // MIME type, "" when unknown: https://developer.mozilla.org/en-US/docs/Web/API/Blob/type
+	LastModified() time.Time // https://developer.mozilla.org/en-US/docs/Web/API/File/lastModified
+	Bytes() ([]byte, error)  // https://developer.mozilla.org/en-US/docs/Web/API/Blob/arrayBuffer
+	Text() (string, error)   // https://developer.mozilla.org/en-US/docs/Web/API/Blob/text
+}
+
+type fileS struct {
+	ValueI
+}
+
+var _ FileI = fileS{}
+
+func NewFile(val ValueI) fileS {
+	return fileS{ValueI: val}
+}
+
+// fileListToFiles converts a FileList.
+func fileListToFiles(list ValueI) []FileI {
+	if list.IsNull() || list.IsUndefined() {
+		return nil
+	}
+	var out []FileI
+	for _, val := range nodeListToObjects(list) {
+		out = append(out, NewFile(val))
+	}
+	return out
+}
+
+func (f fileS) Underlying() ValueI { return f.ValueI }
+
+func (f fileS) Name() string { return f.Get("name").String() }
+func (f fileS) Size() int    { return f.Get("size").Int() }
+func (f fileS) Type() string { return f.Get("type").String() }
+
+func (f fileS) LastModified() time.Time {
+	return time.UnixMilli(int64(f.Get("lastModified").Float()))
+}
+
+func (f fileS) Bytes() ([]byte, error) {
+	result := <-awaitPromise(f.Call("arrayBuffer"))
+	if result.err != nil {
+		return nil, result.err
+	}
+	return bytesFromValue(result.value), nil
+}
+
+func (f fileS) Text() (string, error) {
+	result := <-awaitPromise(f.Call("text"))
+	if result.err != nil {
+		return "", result.err
+	}
+	if result.value.Type() != TypeString {
+		return "", errors.New("file: text() did not return a string")
+	}
+	return result.value.String(), nil
+}
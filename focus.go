@@ -0,0 +1,478 @@
+package dom
+
+import "sync"
+
+/*
+Keyboard focus. A focus trap keeps Tab inside modal content, a roving
+tabindex makes a toolbar or grid a single Tab stop that is moved through
+with the arrow keys, and SaveFocus puts focus back where it was when a view
+closes:
+
+	trap := NewFocusTrap(dialog, FocusTrapOptionsT{OnEscape: closeDialog})
+	defer trap.Release() // also restores the focus
+
+	NewRovingTabIndex(toolbar, toolbar.QuerySelectorAll("button"), RovingTabIndexOptionsT{Wrap: true})
+
+Focus only moves between elements in the page, so under the simulated
+backend these helpers only keep their bookkeeping.
+*/
+
+// https://developer.mozilla.org/en-US/docs/Web/API/FocusEvent
+type FocusEventI interface {
+	EventI
+
+	// RelatedTarget is the element losing focus for focusin and the one
+	// gaining it for focusout, or nil when focus comes from or goes outside
+	// the page. https://developer.mozilla.org/en-US/docs/Web/API/FocusEvent/relatedTarget
+	RelatedTarget() ElementI
+}
+
+type focusEventS struct {
+	EventI
+}
+
+var _ FocusEventI = focusEventS{}
+
+// AsFocusEvent gives access to the focus properties of a focus, blur,
+// focusin or focusout event.
+func AsFocusEvent(e EventI) FocusEventI {
+	return focusEventS{EventI: e}
+}
+
+func (e focusEventS) RelatedTarget() ElementI {
+	return observedNode(e.Underlying().Get("relatedTarget"))
+}
+
+// OnFocusIn adds a listener for focus moving to the element or one of its
+// descendants. Unlike focus, focusin bubbles.
+func (e *elementS) OnFocusIn(listener func(FocusEventI)) EventListenerI {
+	return e.AddEventListener("focusin", false, func(ev EventI) {
+		listener(AsFocusEvent(ev))
+	})
+}
+
+// OnFocusOut adds a listener for focus leaving the element or one of its
+// descendants. Unlike blur, focusout bubbles.
+func (e *elementS) OnFocusOut(listener func(FocusEventI)) EventListenerI {
+	return e.AddEventListener("focusout", false, func(ev EventI) {
+		listener(AsFocusEvent(ev))
+	})
+}
+
+////
+////
+////
+
+// focusableSelector matches the elements that can take focus, before
+// checking whether they are hidden or left out of the Tab order.
+const focusableSelector = `a[href], area[href], button:not([disabled]), input:not([disabled]):not([type="hidden"]), ` +
+	`select:not([disabled]), textarea:not([disabled]), iframe, audio[controls], video[controls], summary, ` +
+	`[contenteditable]:not([contenteditable="false"]), [tabindex]`
+
+// Focusables returns the elements below root that Tab moves through, in
+// document order. Elements that are not rendered, or have a negative
+// tabindex, are left out. The elements are wrapped without changing them.
+func Focusables(root ElementI) []ElementI {
+	var out []ElementI
+	for _, val := range nodeListToObjects(root.Underlying().Call("querySelectorAll", focusableSelector)) {
+		if val.Get("tabIndex").Int() < 0 || val.Call("getClientRects").Length() == 0 {
+			continue
+		}
+		out = append(out, observedNode(val))
+	}
+	return out
+}
+
+// indexOfNode returns the position of the element wrapping val, or -1.
+func indexOfNode(elements []ElementI, val ValueI) int {
+	for i, e := range elements {
+		if e.Underlying().Equal(val) {
+			return i
+		}
+	}
+	return -1
+}
+
+// eventKey returns the key of a keyboard event.
+// https://developer.mozilla.org/en-US/docs/Web/API/KeyboardEvent/key
+func eventKey(ev EventI) string {
+	return ev.Underlying().Get("key").String()
+}
+
+// SaveFocus remembers the focused element and returns a function that
+// focuses it again, such as when a dialog or a view opened from it closes.
+// restore does nothing if the element has left the page by then.
+func SaveFocus() (restore func()) {
+	active := Doc.Underlying().Get("activeElement")
+	return func() {
+		if active.IsNull() || active.IsUndefined() || !active.Get("isConnected").Truthy() {
+			return
+		}
+		active.Call("focus")
+	}
+}
+
+// RestoreFocusOnRemove puts focus back on the element focused now once view
+// is removed. Call it when opening the view.
+func RestoreFocusOnRemove(view ElementI) (cancel func()) {
+	return view.OnRemove(SaveFocus())
+}
+
+////
+////
+////
+
+// FocusTrapOptionsT configures NewFocusTrap.
+type FocusTrapOptionsT struct {
+	InitialFocus ElementI // focused when the trap starts, the first focusable element when nil
+	OnEscape     func()   // called in a new goroutine when Escape is pressed inside the trap, usually to close it
+}
+
+// FocusTrapS keeps keyboard focus inside a container, such as a modal
+// dialog. Tab and Shift+Tab wrap around at the ends, and focus moving
+// outside by other means is brought back.
+type FocusTrapS struct {
+	container ElementI
+	opts      FocusTrapOptionsT
+	listeners *ListenerGroupS
+	restore   func()
+	once      sync.Once
+
+	mutex        sync.Mutex
+	cancelRemove func() // nil until OnRemove has returned
+}
+
+// NewFocusTrap remembers the focused element, moves focus into container
+// and starts trapping it. Release stops the trap and restores the focus, as
+// does removing the container.
+func NewFocusTrap(container ElementI, opts FocusTrapOptionsT) *FocusTrapS {
+	ret := &FocusTrapS{
+		container: container,
+		opts:      opts,
+		listeners: NewListenerGroup(),
+		restore:   SaveFocus(),
+	}
+
+	// both listeners have to act during dispatch to keep the browser from moving focus
+	syncOpts := ListenerOptionsT{Sync: true}
+	ret.listeners.AddWithOptions(container, "keydown", syncOpts, ret.onKeyDown)
+	ret.listeners.AddWithOptions(Doc, "focusin", syncOpts, ret.onFocusIn)
+	cancelRemove := container.OnRemove(ret.Release)
+	ret.mutex.Lock()
+	ret.cancelRemove = cancelRemove
+	ret.mutex.Unlock()
+
+	ret.focusInitial()
+	return ret
+}
+
+func (t *FocusTrapS) focusInitial() {
+	if t.opts.InitialFocus != nil {
+		t.opts.InitialFocus.Focus()
+		return
+	}
+	t.focusFirst()
+}
+
+// focusFirst focuses the first focusable element, or the container itself
+// when there is none.
+func (t *FocusTrapS) focusFirst() {
+	items := Focusables(t.container)
+	if len(items) == 0 {
+		if !t.container.HasAttribute("tabindex") {
+			t.container.SetAttribute("tabindex", "-1")
+		}
+		t.container.Focus()
+		return
+	}
+	items[0].Focus()
+}
+
+func (t *FocusTrapS) onKeyDown(ev EventI) {
+	switch eventKey(ev) {
+	case "Escape":
+		if t.opts.OnEscape != nil {
+			go t.opts.OnEscape()
+		}
+	case "Tab":
+		items := Focusables(t.container)
+		if len(items) == 0 {
+			ev.PreventDefault()
+			return
+		}
+		backwards := ev.Underlying().Get("shiftKey").Truthy()
+		index := indexOfNode(items, ev.Underlying().Get("target"))
+		if next, ok := focusTrapNext(index, len(items), backwards); ok {
+			ev.PreventDefault()
+			items[next].Focus()
+		}
+	}
+}
+
+// focusTrapNext returns where Tab should go from the focusable element at
+// index, when the trap has to step in instead of the browser.
+func focusTrapNext(index, count int, backwards bool) (int, bool) {
+	switch {
+	case index < 0 && backwards:
+		return count - 1, true
+	case index < 0:
+		return 0, true
+	case backwards && index == 0:
+		return count - 1, true
+	case !backwards && index == count-1:
+		return 0, true
+	}
+	return -1, false
+}
+
+func (t *FocusTrapS) onFocusIn(ev EventI) {
+	target := ev.Underlying().Get("target")
+	if t.container.Underlying().Call("contains", target).Truthy() {
+		return
+	}
+	t.focusFirst()
+}
+
+// Release stops trapping focus and focuses the element that was focused
+// when the trap started. Calling it again does nothing.
+func (t *FocusTrapS) Release() {
+	t.once.Do(func() {
+		t.listeners.Release()
+		t.mutex.Lock()
+		cancelRemove := t.cancelRemove
+		t.mutex.Unlock()
+		// nil when the container was removed while the trap was created
+		if cancelRemove != nil {
+			cancelRemove()
+		}
+		t.restore()
+	})
+}
+
+////
+////
+////
+
+// RovingOrientation selects the arrow keys that move through a roving
+// tabindex group.
+type RovingOrientation string
+
+const (
+	RovingOrientation_Horizontal RovingOrientation = "horizontal" // Left and Right, for toolbars and tabs
+	RovingOrientation_Vertical   RovingOrientation = "vertical"   // Up and Down, for menus and lists
+	RovingOrientation_Both       RovingOrientation = "both"       // every arrow moves to the previous or next item
+	RovingOrientation_Grid       RovingOrientation = "grid"       // Left and Right move by one, Up and Down by a row of Columns
+)
+
+// RovingTabIndexOptionsT configures NewRovingTabIndex.
+type RovingTabIndexOptionsT struct {
+	Orientation RovingOrientation // horizontal when empty
+	Columns     int               // items per row, for RovingOrientation_Grid
+	Wrap        bool              // moving past one end goes to the other, except between rows of a grid
+
+	OnMove func(item ElementI, index int) // called in a new goroutine when the arrow keys move the focus
+}
+
+// RovingTabIndexS makes a group of items, such as the buttons of a toolbar
+// or the cells of a grid, a single Tab stop. Only the current item has
+// tabindex 0, and the arrow keys, Home and End move the focus between the
+// items. Clicking or otherwise focusing an item makes it current.
+type RovingTabIndexS struct {
+	container ElementI
+	opts      RovingTabIndexOptionsT
+	listeners *ListenerGroupS
+	once      sync.Once
+
+	mutex        sync.Mutex
+	items        []ElementI
+	current      int
+	cancelRemove func() // nil until OnRemove has returned
+}
+
+// NewRovingTabIndex makes the first of items, which are inside container,
+// the Tab stop. The group is released when container is removed.
+func NewRovingTabIndex(container ElementI, items []ElementI, opts RovingTabIndexOptionsT) *RovingTabIndexS {
+	if opts.Orientation == "" {
+		opts.Orientation = RovingOrientation_Horizontal
+	}
+	ret := &RovingTabIndexS{
+		container: container,
+		opts:      opts,
+		listeners: NewListenerGroup(),
+	}
+	ret.SetItems(items)
+
+	// keydown is handled during dispatch so the arrow keys do not scroll
+	syncOpts := ListenerOptionsT{Sync: true}
+	ret.listeners.AddWithOptions(container, "keydown", syncOpts, ret.onKeyDown)
+	ret.listeners.AddWithOptions(container, "focusin", syncOpts, ret.onFocusIn)
+	cancelRemove := container.OnRemove(ret.Release)
+	ret.mutex.Lock()
+	ret.cancelRemove = cancelRemove
+	ret.mutex.Unlock()
+	return ret
+}
+
+// SetItems replaces the items, such as after the group's contents changed.
+// The current position is kept where possible.
+func (r *RovingTabIndexS) SetItems(items []ElementI) {
+	r.mutex.Lock()
+	r.items = items
+	r.current = min(r.current, max(len(items)-1, 0))
+	current := r.current
+	r.mutex.Unlock()
+
+	r.setTabStop(items, current)
+}
+
+func (r *RovingTabIndexS) Items() []ElementI {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return r.items
+}
+
+// Current returns the item that is the Tab stop, or nil without items.
+func (r *RovingTabIndexS) Current() ElementI {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if len(r.items) == 0 {
+		return nil
+	}
+	return r.items[r.current]
+}
+
+func (r *RovingTabIndexS) CurrentIndex() int {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return r.current
+}
+
+// Focus makes the item at index the Tab stop and focuses it.
+func (r *RovingTabIndexS) Focus(index int) {
+	if item := r.moveTo(index); item != nil {
+		item.Focus()
+	}
+}
+
+// moveTo makes the item at index the Tab stop and returns it, or nil if
+// there is no such item.
+func (r *RovingTabIndexS) moveTo(index int) ElementI {
+	r.mutex.Lock()
+	if index < 0 || index >= len(r.items) {
+		r.mutex.Unlock()
+		return nil
+	}
+	items := r.items
+	r.current = index
+	r.mutex.Unlock()
+
+	r.setTabStop(items, index)
+	return items[index]
+}
+
+func (r *RovingTabIndexS) setTabStop(items []ElementI, current int) {
+	for i, item := range items {
+		if i == current {
+			item.SetTabIndex(0)
+		} else {
+			item.SetTabIndex(-1)
+		}
+	}
+}
+
+func (r *RovingTabIndexS) onKeyDown(ev EventI) {
+	items := r.Items()
+	index := indexOfNode(items, ev.Underlying().Get("target"))
+	if index < 0 {
+		return
+	}
+	next, ok := rovingNext(eventKey(ev), index, len(items), r.opts)
+	if !ok {
+		return
+	}
+	ev.PreventDefault()
+	r.Focus(next)
+	if r.opts.OnMove != nil {
+		go r.opts.OnMove(items[next], next)
+	}
+}
+
+func (r *RovingTabIndexS) onFocusIn(ev EventI) {
+	items := r.Items()
+	if index := indexOfNode(items, ev.Underlying().Get("target")); index >= 0 {
+		r.moveTo(index)
+	}
+}
+
+// rovingNext returns the item that key moves to from index, and false for
+// keys the group does not handle or moves past the ends without Wrap.
+func rovingNext(key string, index, count int, opts RovingTabIndexOptionsT) (int, bool) {
+	switch key {
+	case "Home":
+		return 0, true
+	case "End":
+		return count - 1, true
+	}
+
+	prev, next := "", ""
+	row := 1
+	switch opts.Orientation {
+	case RovingOrientation_Vertical:
+		prev, next = "ArrowUp", "ArrowDown"
+	case RovingOrientation_Both:
+		if key == "ArrowUp" {
+			key = "ArrowLeft"
+		} else if key == "ArrowDown" {
+			key = "ArrowRight"
+		}
+		prev, next = "ArrowLeft", "ArrowRight"
+	case RovingOrientation_Grid:
+		row = max(opts.Columns, 1)
+		fallthrough
+	default:
+		prev, next = "ArrowLeft", "ArrowRight"
+	}
+
+	delta := 0
+	switch key {
+	case prev:
+		delta = -1
+	case next:
+		delta = 1
+	case "ArrowUp":
+		if opts.Orientation == RovingOrientation_Grid {
+			delta = -row
+		}
+	case "ArrowDown":
+		if opts.Orientation == RovingOrientation_Grid {
+			delta = row
+		}
+	}
+	if delta == 0 {
+		return index, false
+	}
+
+	target := index + delta
+	if target >= 0 && target < count {
+		return target, true
+	}
+	if opts.Wrap && (delta == 1 || delta == -1) {
+		return (target + count) % count, true
+	}
+	return index, false
+}
+
+// Release removes the group's listeners. The tabindex of the items is left
+// as it is. Calling it again does nothing.
+func (r *RovingTabIndexS) Release() {
+	r.once.Do(func() {
+		r.listeners.Release()
+		r.mutex.Lock()
+		cancelRemove := r.cancelRemove
+		r.mutex.Unlock()
+		// nil when the container was removed while the group was created
+		if cancelRemove != nil {
+			cancelRemove()
+		}
+	})
+}
@@ -0,0 +1,148 @@
+package dom
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFocusTrapNext(t *testing.T) {
+	type result struct {
+		Index int
+		Moved bool
+	}
+	tests := []struct {
+		index     int
+		backwards bool
+		expected  result
+	}{
+		{index: -1, expected: result{0, true}},
+		{index: -1, backwards: true, expected: result{2, true}},
+		{index: 0, backwards: true, expected: result{2, true}},
+		{index: 2, expected: result{0, true}},
+		{index: 1, expected: result{-1, false}},
+		{index: 1, backwards: true, expected: result{-1, false}},
+	}
+	for _, test := range tests {
+		index, moved := focusTrapNext(test.index, 3, test.backwards)
+		if got := (result{index, moved}); got != test.expected {
+			t.Errorf("expected: %+v but found: %+v\n", test.expected, got)
+		}
+	}
+}
+
+func TestRovingNext(t *testing.T) {
+	type result struct {
+		Index int
+		Moved bool
+	}
+	horizontal := RovingTabIndexOptionsT{Orientation: RovingOrientation_Horizontal}
+	vertical := RovingTabIndexOptionsT{Orientation: RovingOrientation_Vertical, Wrap: true}
+	grid := RovingTabIndexOptionsT{Orientation: RovingOrientation_Grid, Columns: 3, Wrap: true}
+
+	tests := []struct {
+		key      string
+		index    int
+		opts     RovingTabIndexOptionsT
+		expected result
+	}{
+		{"ArrowRight", 0, horizontal, result{1, true}},
+		{"ArrowLeft", 0, horizontal, result{0, false}},
+		{"ArrowDown", 0, horizontal, result{0, false}},
+		{"End", 0, horizontal, result{5, true}},
+		{"ArrowUp", 0, vertical, result{5, true}},
+		{"ArrowDown", 5, vertical, result{0, true}},
+		{"ArrowDown", 1, grid, result{4, true}},
+		{"ArrowDown", 4, grid, result{4, false}},
+		{"ArrowRight", 5, grid, result{0, true}},
+		{"Home", 4, grid, result{0, true}},
+		{"a", 2, grid, result{2, false}},
+	}
+	for _, test := range tests {
+		index, moved := rovingNext(test.key, test.index, 6, test.opts)
+		if got := (result{index, moved}); got != test.expected {
+			t.Errorf("%s from %d: expected: %+v but found: %+v\n", test.key, test.index, test.expected, got)
+		}
+	}
+}
+
+func TestRovingTabIndexItems(t *testing.T) {
+	container := NewElement(valueS{})
+	items := []ElementI{NewElement(valueS{}), NewElement(valueS{}), NewElement(valueS{})}
+	roving := NewRovingTabIndex(container, items, RovingTabIndexOptionsT{})
+
+	if roving.Current() != items[0] {
+		t.Errorf("expected: %+v but found: %+v\n", items[0].ID(), roving.Current().ID())
+	}
+	roving.Focus(2)
+	roving.Focus(7)
+	if roving.CurrentIndex() != 2 {
+		t.Errorf("expected: %+v but found: %+v\n", 2, roving.CurrentIndex())
+	}
+
+	// fewer items keep the position within them
+	roving.SetItems(items[:2])
+	if roving.Current() != items[1] {
+		t.Errorf("expected: %+v but found: %+v\n", items[1].ID(), roving.Current().ID())
+	}
+	roving.SetItems(nil)
+	if roving.Current() != nil || !reflect.DeepEqual(roving.Items(), []ElementI(nil)) {
+		t.Errorf("expected: no items but found: %+v\n", roving.Items())
+	}
+	container.Remove()
+}
+
+func TestFocusHelpersReleasedWithContainer(t *testing.T) {
+	registered := nodeRegistry.len()
+	val := fakeNode("div", nil)
+	container := newNode(val)
+	trap := NewFocusTrap(container, FocusTrapOptionsT{})
+	roving := NewRovingTabIndex(container, []ElementI{NewElement(valueS{})}, RovingTabIndexOptionsT{})
+
+	// each listener on the container is removed once: the trap's keydown
+	// and the roving tabindex's keydown and focusin
+	container.Remove()
+	trap.Release()
+	roving.Release()
+	if removed := val.count("removeEventListener"); removed != 3 {
+		t.Errorf("expected: %+v but found: %+v\n", 3, removed)
+	}
+	if nodeRegistry.len() != registered {
+		t.Errorf("expected: %+v but found: %+v\n", registered, nodeRegistry.len())
+	}
+}
+
+func TestRovingTabIndexReleaseCancelsOnRemove(t *testing.T) {
+	container := NewElement(valueS{})
+	roving := NewRovingTabIndex(container, nil, RovingTabIndexOptionsT{})
+	roving.Release()
+
+	container.mutex.Lock()
+	left := len(container.cleanups)
+	container.mutex.Unlock()
+	if left != 0 {
+		t.Errorf("expected: %+v but found: %+v\n", 0, left)
+	}
+	container.Remove()
+}
+
+// removingElementS is removed while OnRemove registers, as by another
+// goroutine removing it at that moment.
+type removingElementS struct {
+	*elementS
+}
+
+func (e removingElementS) OnRemove(fn func()) (cancel func()) {
+	fn()
+	return func() {}
+}
+
+func TestFocusHelpersRemovedWhileCreated(t *testing.T) {
+	container := removingElementS{NewElement(valueS{})}
+	trap := NewFocusTrap(container, FocusTrapOptionsT{})
+	roving := NewRovingTabIndex(container, nil, RovingTabIndexOptionsT{})
+
+	// already released, so these do nothing
+	trap.Release()
+	roving.Release()
+	container.Remove()
+}
@@ -0,0 +1,443 @@
+package dom
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+/*
+Binds a <form> to a Go struct. Fields map to the form controls with the
+same name. The name comes from the "form" tag, otherwise from the field
+name in kebab case, so FirstName maps to name="first-name".
+
+	type signup struct {
+		Email   string    `form:"email,required"`
+		Age     int       `form:"age"`
+		News    bool      `form:"news"` // checkbox
+		Plan    string    `form:"plan"` // radio group or select
+		Tags    []string  `form:"tags"` // multi-select or checkboxes sharing a name
+		Born    time.Time `form:"born"` // date input
+		Scratch string    `form:"-"`
+	}
+
+	b, _ := BindForm(formElement, &s)
+	b.Fill()
+	b.OnSubmit(func(errs FormErrorsT) { ... })
+
+The "required" option rejects empty values in addition to the browser's own
+constraint validation.
+*/
+
+// ErrRequired is reported for a required field that was left empty.
+var ErrRequired = errors.New("required")
+
+// FormErrorsT maps struct field names to what was wrong with their value.
+type FormErrorsT map[string]error
+
+func (e FormErrorsT) Error() string {
+	fields := make([]string, 0, len(e))
+	for field := range e {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	parts := make([]string, 0, len(fields))
+	for _, field := range fields {
+		parts = append(parts, fmt.Sprintf("%s: %v", field, e[field]))
+	}
+	return strings.Join(parts, "; ")
+}
+
+type formFieldT struct {
+	field    string
+	name     string
+	index    []int
+	required bool
+}
+
+type FormBindingS struct {
+	form           ElementI
+	target         reflect.Value
+	fields         []formFieldT
+	submitListener EventListenerI
+}
+
+// BindForm binds form to the struct pointed to by target.
+func BindForm(form ElementI, target any) (*FormBindingS, error) {
+	rv := reflect.ValueOf(target)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return nil, fmt.Errorf("form: expected a non-nil pointer to a struct but got %T", target)
+	}
+
+	ret := &FormBindingS{
+		form:   form,
+		target: rv.Elem(),
+		fields: formFields(rv.Elem().Type()),
+	}
+	return ret, nil
+}
+
+// formFields lists the exported fields of t with their control names.
+func formFields(t reflect.Type) []formFieldT {
+	var out []formFieldT
+	for _, sf := range reflect.VisibleFields(t) {
+		if !sf.IsExported() || sf.Anonymous {
+			continue
+		}
+		tag := sf.Tag.Get("form")
+		if tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+		if name == "" {
+			name = kebabCase(sf.Name)
+		}
+		out = append(out, formFieldT{
+			field:    sf.Name,
+			name:     name,
+			index:    sf.Index,
+			required: hasTagOption(opts, "required"),
+		})
+	}
+	return out
+}
+
+func hasTagOption(opts, option string) bool {
+	for _, opt := range strings.Split(opts, ",") {
+		if opt == option {
+			return true
+		}
+	}
+	return false
+}
+
+// formControls groups the form's controls by name. The controls are
+// wrapped without being given ids.
+func formControls(form ElementI) map[string][]ElementI {
+	ret := map[string][]ElementI{}
+	for _, control := range observedNodes(form.Underlying().Get("elements")) {
+		name := control.Underlying().Get("name").String()
+		if name == "" {
+			continue
+		}
+		ret[name] = append(ret[name], control)
+	}
+	return ret
+}
+
+// controlKind tells how a group of controls holds its value.
+func controlKind(e ElementI) string {
+	switch strings.ToLower(e.TagName()) {
+	case "select":
+		return "select"
+	case "input":
+		switch AsInput(e).Type() {
+		case InputType_Checkbox:
+			return "checkbox"
+		case InputType_Radio:
+			return "radio"
+		}
+	}
+	return "value"
+}
+
+// Fill copies the struct's field values into the form.
+func (b *FormBindingS) Fill() error {
+	controls := formControls(b.form)
+	for _, f := range b.fields {
+		group := controls[f.name]
+		if len(group) == 0 {
+			continue
+		}
+		fv, err := b.target.FieldByIndexErr(f.index)
+		if err != nil {
+			// inside a nil embedded struct pointer, so there is no value
+			continue
+		}
+		if err := fillControls(group, fv); err != nil {
+			return fmt.Errorf("form: field %s: %w", f.field, err)
+		}
+	}
+	return nil
+}
+
+func fillControls(group []ElementI, fv reflect.Value) error {
+	first := group[0]
+	switch controlKind(first) {
+	case "checkbox":
+		if indirectType(fv.Type()).Kind() == reflect.Bool {
+			s, err := formatValue(fv)
+			if err != nil {
+				return err
+			}
+			AsInput(first).SetChecked(s == "true")
+			return nil
+		}
+		values, err := formatValues(fv)
+		if err != nil {
+			return err
+		}
+		checked := map[string]bool{}
+		for _, v := range values {
+			checked[v] = true
+		}
+		for _, e := range group {
+			box := AsInput(e)
+			box.SetChecked(checked[box.Value()])
+		}
+		return nil
+	case "radio":
+		s, err := formatValue(fv)
+		if err != nil {
+			return err
+		}
+		for _, e := range group {
+			radio := AsInput(e)
+			radio.SetChecked(radio.Value() == s)
+		}
+		return nil
+	case "select":
+		sel := AsSelect(first)
+		if fv.Kind() == reflect.Slice {
+			values, err := formatValues(fv)
+			if err != nil {
+				return err
+			}
+			sel.SetSelectedValues(values)
+			return nil
+		}
+		s, err := formatValue(fv)
+		if err != nil {
+			return err
+		}
+		sel.SetValue(s)
+		return nil
+	default:
+		s, err := formatControlValue(fv, AsInput(first).Type())
+		if err != nil {
+			return err
+		}
+		formControlS{ElementI: first}.SetValue(s)
+		return nil
+	}
+}
+
+// Read copies the form's values into the struct. Fields that fail to
+// convert or validate keep their previous value and are reported in the
+// returned map, which is nil when every field was read.
+func (b *FormBindingS) Read() FormErrorsT {
+	var errs FormErrorsT
+	controls := formControls(b.form)
+	for _, f := range b.fields {
+		group := controls[f.name]
+		if len(group) == 0 {
+			continue
+		}
+		fv, err := settableField(b.target, f.index)
+		if err == nil {
+			err = readControls(group, fv, f.required)
+		}
+		if err != nil {
+			if errs == nil {
+				errs = FormErrorsT{}
+			}
+			errs[f.field] = err
+		}
+	}
+	return errs
+}
+
+func readControls(group []ElementI, fv reflect.Value, required bool) error {
+	for _, e := range group {
+		control := formControlS{ElementI: e}
+		if !control.CheckValidity() {
+			return errors.New(control.ValidationMessage())
+		}
+	}
+
+	// parse into a copy so a bad value leaves the field untouched
+	tmp := reflect.New(fv.Type()).Elem()
+	tmp.Set(fv)
+
+	first := group[0]
+	switch controlKind(first) {
+	case "checkbox":
+		if indirectType(tmp.Type()).Kind() == reflect.Bool {
+			checked := AsInput(first).Checked()
+			if required && !checked {
+				return ErrRequired
+			}
+			if err := parseValue(strconv.FormatBool(checked), tmp); err != nil {
+				return err
+			}
+			break
+		}
+		var values []string
+		for _, e := range group {
+			if box := AsInput(e); box.Checked() {
+				values = append(values, box.Value())
+			}
+		}
+		if required && len(values) == 0 {
+			return ErrRequired
+		}
+		if err := parseValues(values, tmp); err != nil {
+			return err
+		}
+	case "radio":
+		s := ""
+		for _, e := range group {
+			if radio := AsInput(e); radio.Checked() {
+				s = radio.Value()
+			}
+		}
+		if err := parseControlValue(s, tmp, "", required); err != nil {
+			return err
+		}
+	case "select":
+		sel := AsSelect(first)
+		if tmp.Kind() == reflect.Slice {
+			values := sel.SelectedValues()
+			if required && len(values) == 0 {
+				return ErrRequired
+			}
+			if err := parseValues(values, tmp); err != nil {
+				return err
+			}
+			break
+		}
+		if err := parseControlValue(sel.Value(), tmp, "", required); err != nil {
+			return err
+		}
+	default:
+		s := formControlS{ElementI: first}.Value()
+		if err := parseControlValue(s, tmp, AsInput(first).Type(), required); err != nil {
+			return err
+		}
+	}
+
+	fv.Set(tmp)
+	return nil
+}
+
+// OnSubmit stops the form from being submitted to the server. Instead the
+// values are read into the struct and handler is called with any errors.
+func (b *FormBindingS) OnSubmit(handler func(errs FormErrorsT)) {
+	b.Release()
+	opts := ListenerOptionsT{PreventDefault: true}
+	b.submitListener = b.form.AddEventListenerWithOptions("submit", opts, func(e EventI) {
+		handler(b.Read())
+	})
+}
+
+// Release removes the submit listener added by OnSubmit.
+func (b *FormBindingS) Release() {
+	if b.submitListener != nil {
+		b.form.RemoveEventListener(b.submitListener)
+		b.submitListener = nil
+	}
+}
+
+////
+////
+////
+
+// controlTimeLayouts are the value formats of the date and time inputs.
+var controlTimeLayouts = map[InputType]string{
+	InputType_Date:          "2006-01-02",
+	InputType_DateTimeLocal: "2006-01-02T15:04",
+	InputType_Month:         "2006-01",
+	InputType_Time:          "15:04",
+}
+
+// formatControlValue is formatValue, except times use the format of the input type.
+func formatControlValue(fv reflect.Value, typ InputType) (string, error) {
+	if layout, ok := controlTimeLayouts[typ]; ok && indirectType(fv.Type()) == timeType {
+		if fv.Kind() == reflect.Ptr {
+			if fv.IsNil() {
+				return "", nil
+			}
+			fv = fv.Elem()
+		}
+		t := fv.Interface().(time.Time)
+		if t.IsZero() {
+			return "", nil
+		}
+		return t.Format(layout), nil
+	}
+	return formatValue(fv)
+}
+
+// parseControlValue is parseValue with form semantics: an empty value is the
+// zero value, or ErrRequired when required.
+func parseControlValue(s string, fv reflect.Value, typ InputType, required bool) error {
+	if s == "" {
+		if required {
+			return ErrRequired
+		}
+		fv.Set(reflect.Zero(fv.Type()))
+		return nil
+	}
+
+	if layout, ok := controlTimeLayouts[typ]; ok && indirectType(fv.Type()) == timeType {
+		t, err := time.Parse(layout, s)
+		if err != nil && typ == InputType_DateTimeLocal {
+			// browsers add seconds when the step allows them
+			t, err = time.Parse("2006-01-02T15:04:05", s)
+		}
+		if err != nil {
+			return err
+		}
+		if fv.Kind() == reflect.Ptr {
+			fv.Set(reflect.New(timeType))
+			fv = fv.Elem()
+		}
+		fv.Set(reflect.ValueOf(t))
+		return nil
+	}
+	return parseValue(s, fv)
+}
+
+// indirectType returns what t points to, or t when it is not a pointer.
+func indirectType(t reflect.Type) reflect.Type {
+	if t.Kind() == reflect.Ptr {
+		return t.Elem()
+	}
+	return t
+}
+
+// formatValues formats each element of the slice fv.
+func formatValues(fv reflect.Value) ([]string, error) {
+	if fv.Kind() != reflect.Slice {
+		return nil, fmt.Errorf("expected a slice but got %s", fv.Type())
+	}
+	out := make([]string, 0, fv.Len())
+	for i := range fv.Len() {
+		s, err := formatValue(fv.Index(i))
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, s)
+	}
+	return out, nil
+}
+
+// parseValues parses each of values into a new slice stored in fv.
+func parseValues(values []string, fv reflect.Value) error {
+	if fv.Kind() != reflect.Slice {
+		return fmt.Errorf("expected a slice but got %s", fv.Type())
+	}
+	out := reflect.MakeSlice(fv.Type(), len(values), len(values))
+	for i, s := range values {
+		if err := parseValue(s, out.Index(i)); err != nil {
+			return err
+		}
+	}
+	fv.Set(out)
+	return nil
+}
@@ -0,0 +1,223 @@
+package dom
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestFormFields(t *testing.T) {
+	type signup struct {
+		Email     string `form:"email,required"`
+		FirstName string
+		Scratch   string `form:"-"`
+	}
+	found := formFields(reflect.TypeOf(signup{}))
+	expected := []formFieldT{
+		{field: "Email", name: "email", index: []int{0}, required: true},
+		{field: "FirstName", name: "first-name", index: []int{1}},
+	}
+	if !reflect.DeepEqual(expected, found) {
+		t.Errorf("expected: %+v but found: %+v\n", expected, found)
+	}
+}
+
+func TestParseControlValue(t *testing.T) {
+	var age int
+	if err := parseControlValue("", reflect.ValueOf(&age).Elem(), InputType_Number, true); !errors.Is(err, ErrRequired) {
+		t.Errorf("expected: %v but found: %v\n", ErrRequired, err)
+	}
+
+	age = 7
+	if err := parseControlValue("", reflect.ValueOf(&age).Elem(), InputType_Number, false); err != nil || age != 0 {
+		t.Errorf("expected an empty value to clear the field but found: %d, %v\n", age, err)
+	}
+
+	var born time.Time
+	if err := parseControlValue("2001-02-03", reflect.ValueOf(&born).Elem(), InputType_Date, false); err != nil {
+		t.Errorf("unexpected error: %+v\n", err)
+	}
+	s, err := formatControlValue(reflect.ValueOf(born), InputType_Date)
+	if err != nil || s != "2001-02-03" {
+		t.Errorf("expected: %s but found: %s, %v\n", "2001-02-03", s, err)
+	}
+
+	var local time.Time
+	if err := parseControlValue("2001-02-03T04:05:06", reflect.ValueOf(&local).Elem(), InputType_DateTimeLocal, false); err != nil {
+		t.Errorf("unexpected error: %+v\n", err)
+	}
+}
+
+func TestParseValues(t *testing.T) {
+	var ids []int
+	if err := parseValues([]string{"3", "1"}, reflect.ValueOf(&ids).Elem()); err != nil {
+		t.Errorf("unexpected error: %+v\n", err)
+	}
+	if !reflect.DeepEqual([]int{3, 1}, ids) {
+		t.Errorf("expected: %+v but found: %+v\n", []int{3, 1}, ids)
+	}
+
+	values, err := formatValues(reflect.ValueOf(ids))
+	if err != nil || !reflect.DeepEqual([]string{"3", "1"}, values) {
+		t.Errorf("expected: %+v but found: %+v, %v\n", []string{"3", "1"}, values, err)
+	}
+}
+
+func TestFormErrorsString(t *testing.T) {
+	errs := FormErrorsT{"Email": ErrRequired, "Age": errors.New("bad")}
+	expected := "Age: bad; Email: required"
+	if errs.Error() != expected {
+		t.Errorf("expected: %s but found: %s\n", expected, errs.Error())
+	}
+}
+
+func TestFormBindingRoundTrip(t *testing.T) {
+	type signup struct {
+		Email string    `form:"email,required"`
+		Age   int       `form:"age"`
+		News  bool      `form:"news"`
+		Plan  string    `form:"plan"`
+		Tags  []string  `form:"tags"`
+		Langs []string  `form:"langs"`
+		Born  time.Time `form:"born"`
+	}
+	input := func(typ, name, value string) *fakeNodeS {
+		return fakeNode("input", map[string]any{"type": typ, "name": name, "value": value})
+	}
+	email, age, news := input("email", "email", ""), input("number", "age", ""), input("checkbox", "news", "on")
+	free, pro := input("radio", "plan", "free"), input("radio", "plan", "pro")
+	red, blue := input("checkbox", "tags", "red"), input("checkbox", "tags", "blue")
+	goLang, rust := fakeOption("go", false), fakeOption("rust", false)
+	born := input("date", "born", "")
+	form := newNode(fakeNode("form", nil,
+		email, age, news, free, pro, red, blue,
+		fakeNode("select", map[string]any{"name": "langs", "multiple": true}, goLang, rust),
+		fakeNode("div", nil, born),
+	))
+
+	filled := signup{
+		Email: "a@b.c",
+		Age:   30,
+		News:  true,
+		Plan:  "pro",
+		Tags:  []string{"blue"},
+		Langs: []string{"go"},
+		Born:  time.Date(2001, 2, 3, 0, 0, 0, 0, time.UTC),
+	}
+	b, err := BindForm(form, &filled)
+	if err != nil {
+		t.Fatalf("unexpected error: %+v\n", err)
+	}
+	if err := b.Fill(); err != nil {
+		t.Fatalf("unexpected error: %+v\n", err)
+	}
+	for _, c := range []struct {
+		node  *fakeNodeS
+		prop  string
+		value any
+	}{
+		{email, "value", "a@b.c"}, {age, "value", "30"}, {news, "checked", true},
+		{free, "checked", false}, {pro, "checked", true},
+		{red, "checked", false}, {blue, "checked", true},
+		{goLang, "selected", true}, {rust, "selected", false},
+		{born, "value", "2001-02-03"},
+	} {
+		if c.node.props[c.prop] != c.value {
+			t.Errorf("%s %s: expected: %+v but found: %+v\n", c.node.props["name"], c.prop, c.value, c.node.props[c.prop])
+		}
+	}
+
+	var read signup
+	b, _ = BindForm(form, &read)
+	if errs := b.Read(); errs != nil {
+		t.Fatalf("unexpected errors: %+v\n", errs)
+	}
+	if !reflect.DeepEqual(filled, read) {
+		t.Errorf("expected: %+v but found: %+v\n", filled, read)
+	}
+
+	// the controls are wrapped without being given ids
+	if _, ok := email.props["id"]; ok {
+		t.Errorf("expected: no id but found: %+v\n", email.props["id"])
+	}
+}
+
+func TestFormBindingReadErrors(t *testing.T) {
+	type signup struct {
+		Email string `form:"email,required"`
+		Age   int    `form:"age"`
+	}
+	form := newNode(fakeNode("form", nil,
+		fakeNode("input", map[string]any{"type": "email", "name": "email", "value": ""}),
+		fakeNode("input", map[string]any{"type": "number", "name": "age", "value": "old"}),
+	))
+
+	s := signup{Email: "kept", Age: 7}
+	b, _ := BindForm(form, &s)
+	errs := b.Read()
+	if !errors.Is(errs["Email"], ErrRequired) || errs["Age"] == nil || len(errs) != 2 {
+		t.Errorf("expected: errors for Email and Age but found: %+v\n", errs)
+	}
+	if s != (signup{Email: "kept", Age: 7}) {
+		t.Errorf("expected the fields to keep their values but found: %+v\n", s)
+	}
+}
+
+func TestFormBindingPointers(t *testing.T) {
+	type profile struct {
+		News *bool      `form:"news"`
+		Age  *int       `form:"age"`
+		Born *time.Time `form:"born"`
+	}
+	news := fakeNode("input", map[string]any{"type": "checkbox", "name": "news", "value": "on"})
+	age := fakeNode("input", map[string]any{"type": "number", "name": "age", "value": "3"})
+	born := fakeNode("input", map[string]any{"type": "date", "name": "born", "value": ""})
+	form := newNode(fakeNode("form", nil, news, age, born))
+
+	yes := true
+	day := time.Date(2001, 2, 3, 0, 0, 0, 0, time.UTC)
+	filled := profile{News: &yes, Born: &day}
+	b, _ := BindForm(form, &filled)
+	if err := b.Fill(); err != nil {
+		t.Fatalf("unexpected error: %+v\n", err)
+	}
+	if news.props["checked"] != true || age.props["value"] != "" || born.props["value"] != "2001-02-03" {
+		t.Errorf("expected: checked, empty and 2001-02-03 but found: %+v, %+v, %+v\n", news.props["checked"], age.props["value"], born.props["value"])
+	}
+
+	var read profile
+	b, _ = BindForm(form, &read)
+	if errs := b.Read(); errs != nil {
+		t.Fatalf("unexpected errors: %+v\n", errs)
+	}
+	if !reflect.DeepEqual(filled, read) {
+		t.Errorf("expected: %+v but found: %+v\n", filled, read)
+	}
+}
+
+func TestFormBindingEmbeddedPointer(t *testing.T) {
+	type Address struct {
+		City string `form:"city"`
+	}
+	type order struct {
+		Item string `form:"item"`
+		*Address
+	}
+	item := fakeNode("input", map[string]any{"type": "text", "name": "item", "value": ""})
+	city := fakeNode("input", map[string]any{"type": "text", "name": "city", "value": "Oslo"})
+	form := newNode(fakeNode("form", nil, item, city))
+
+	target := order{Item: "lamp"}
+	b, err := BindForm(form, &target)
+	if err != nil {
+		t.Fatalf("unexpected error: %+v\n", err)
+	}
+	// the city is skipped while there is no address to take it from
+	if err := b.Fill(); err != nil || item.props["value"] != "lamp" || city.props["value"] != "Oslo" {
+		t.Errorf("expected: lamp and Oslo but found: %+v, %+v, %v\n", item.props["value"], city.props["value"], err)
+	}
+	if errs := b.Read(); errs != nil || target.Address == nil || target.City != "Oslo" {
+		t.Errorf("expected: an address in Oslo but found: %+v, %v\n", target.Address, errs)
+	}
+}
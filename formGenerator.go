@@ -0,0 +1,318 @@
+package dom
+
+import (
+	"fmt"
+	"image/color"
+	"reflect"
+	"strings"
+)
+
+/*
+GenerateForm builds a form for a struct, with one labelled control per field
+and a slot under each control for its error. It uses the same "form" tag as
+BindForm, plus options describing the control:
+
+	type signup struct {
+		Email string    `form:"email,required,type=email" label:"Email address" placeholder:"you@example.com"`
+		Age   int       `form:"age,min=18,max=120"`
+		Plan  string    `form:"plan,options=free|pro|team"`
+		Notes string    `form:"notes,type=textarea"`
+		Born  time.Time `form:"born"`
+	}
+
+	f, err := GenerateForm(&s, "Sign up")
+	f.OnSubmit(func(errs FormErrorsT) { ... })
+
+The control type follows the field kind: bool is a checkbox, numbers are
+number inputs, time.Time is a date input and anything with options is a
+select, or a multi-select for slices. A pointer field gets the control of
+what it points to, and an empty control reads as nil. A checkbox is never
+empty, so an unchecked *bool reads as false rather than nil. Options can
+also come from the field type by implementing FormOptionsI. The label
+defaults to the field name split into words.
+*/
+
+// FormOptionsI lets an enum type list the values GenerateForm offers in its select.
+type FormOptionsI interface {
+	FormOptions() []string
+}
+
+// formErrorColor is used for error messages and required markers.
+var formErrorColor = color.RGBA{R: 0xb0, G: 0x00, B: 0x20, A: 0xff}
+
+// formControlSpecT describes the control generated for a field.
+type formControlSpecT struct {
+	formFieldT
+	label       string
+	placeholder string
+	tag         string // input, select or textarea
+	typ         InputType
+	attrs       map[string]string
+	options     []string
+	multiple    bool
+}
+
+var formOptionsType = reflect.TypeOf((*FormOptionsI)(nil)).Elem()
+
+// formControlSpecs works out the control for every field of t.
+func formControlSpecs(t reflect.Type) ([]formControlSpecT, error) {
+	var out []formControlSpecT
+	for _, f := range formFields(t) {
+		sf := t.FieldByIndex(f.index)
+		spec := formControlSpecT{
+			formFieldT:  f,
+			label:       sf.Tag.Get("label"),
+			placeholder: sf.Tag.Get("placeholder"),
+			tag:         "input",
+			attrs:       map[string]string{},
+		}
+		if spec.label == "" {
+			spec.label = labelCase(sf.Name)
+		}
+
+		_, opts, _ := strings.Cut(sf.Tag.Get("form"), ",")
+		for _, opt := range strings.Split(opts, ",") {
+			key, value, ok := strings.Cut(opt, "=")
+			if !ok {
+				continue
+			}
+			switch key {
+			case "type":
+				spec.typ = InputType(value)
+			case "min", "max", "step", "pattern", "minlength", "maxlength":
+				spec.attrs[key] = value
+			case "options":
+				spec.options = strings.Split(value, "|")
+			}
+		}
+
+		// a pointer field gets the control of what it points to, left
+		// empty while the pointer is nil
+		ft := sf.Type
+		if ft.Kind() == reflect.Ptr && ft.Elem().Kind() != reflect.Slice {
+			ft = ft.Elem()
+		}
+		elem := ft
+		if ft.Kind() == reflect.Slice {
+			elem = indirectType(ft.Elem())
+			spec.multiple = true
+		}
+		if spec.options == nil {
+			spec.options = formOptionsOf(elem)
+		}
+
+		switch {
+		case spec.typ == "textarea":
+			spec.tag = "textarea"
+			spec.typ = ""
+		case spec.options != nil:
+			spec.tag = "select"
+		case spec.multiple:
+			return nil, fmt.Errorf("form: field %s: slices need options", sf.Name)
+		case spec.typ != "":
+		case ft == timeType:
+			spec.typ = InputType_Date
+		case ft == durationType:
+			spec.typ = InputType_Text
+		default:
+			switch ft.Kind() {
+			case reflect.Bool:
+				spec.typ = InputType_Checkbox
+			case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+				reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+				spec.typ = InputType_Number
+				if _, ok := spec.attrs["step"]; !ok {
+					spec.attrs["step"] = "1"
+				}
+			case reflect.Float32, reflect.Float64:
+				spec.typ = InputType_Number
+				if _, ok := spec.attrs["step"]; !ok {
+					spec.attrs["step"] = "any"
+				}
+			case reflect.String:
+				spec.typ = InputType_Text
+			default:
+				return nil, fmt.Errorf("form: field %s: unsupported type %s", sf.Name, ft)
+			}
+		}
+		out = append(out, spec)
+	}
+	return out, nil
+}
+
+// formOptionsOf returns the options type t lists through FormOptionsI, or
+// nil. Interfaces and pointers have no value to ask, as their zero value is nil.
+func formOptionsOf(t reflect.Type) []string {
+	switch {
+	case t.Kind() == reflect.Interface || t.Kind() == reflect.Ptr:
+		return nil
+	case t.Implements(formOptionsType):
+		return reflect.Zero(t).Interface().(FormOptionsI).FormOptions()
+	case reflect.PointerTo(t).Implements(formOptionsType):
+		return reflect.New(t).Interface().(FormOptionsI).FormOptions()
+	}
+	return nil
+}
+
+// labelCase splits a Go identifier into words: FirstName becomes "First name".
+func labelCase(s string) string {
+	words := strings.Split(kebabCase(s), "-")
+	if len(words) > 0 && words[0] != "" {
+		words[0] = strings.ToUpper(words[0][:1]) + words[0][1:]
+	}
+	return strings.Join(words, " ")
+}
+
+////
+////
+////
+
+// GeneratedFormS is a form built by GenerateForm and bound to its struct.
+type GeneratedFormS struct {
+	ElementI // the <form>
+
+	Binding    *FormBindingS
+	Controls   map[string]ElementI // by struct field name
+	errorSlots map[string]ElementI
+}
+
+// GenerateForm builds a form for the struct pointed to by target, binds it
+// and fills in the current values. An empty submitLabel leaves out the
+// submit button.
+func GenerateForm(target any, submitLabel string) (*GeneratedFormS, error) {
+	rv := reflect.ValueOf(target)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return nil, fmt.Errorf("form: expected a non-nil pointer to a struct but got %T", target)
+	}
+	specs, err := formControlSpecs(rv.Elem().Type())
+	if err != nil {
+		return nil, err
+	}
+
+	ret := &GeneratedFormS{
+		Controls:   map[string]ElementI{},
+		errorSlots: map[string]ElementI{},
+	}
+
+	fields := make([]ElementI, 0, len(specs)+1)
+	for _, spec := range specs {
+		fields = append(fields, ret.newField(spec))
+	}
+	if submitLabel != "" {
+		fields = append(fields, El("button", Attr("type", "submit"), Text(submitLabel)))
+	}
+
+	ret.ElementI = El("form",
+		Class("dom-form"),
+		Attr("novalidate", ""),
+		FlexBox(func(f CSSStyleFlexBoxI) { f.FlexDirection(FlexBoxFlexDirection_Column) }),
+		Children(fields...),
+	)
+
+	ret.Binding, err = BindForm(ret.ElementI, target)
+	if err != nil {
+		return nil, err
+	}
+	if err := ret.Binding.Fill(); err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+// newField builds the label, control and error slot for one field.
+func (g *GeneratedFormS) newField(spec formControlSpecT) ElementI {
+	control := El(spec.tag, Attr("name", spec.name))
+	switch spec.tag {
+	case "select":
+		sel := AsSelect(control)
+		sel.SetMultiple(spec.multiple)
+		if !spec.multiple && !spec.required {
+			sel.AddOption("", "")
+		}
+		for _, option := range spec.options {
+			sel.AddOption(option, option)
+		}
+	case "input":
+		control.SetAttribute("type", string(spec.typ))
+	}
+	for name, value := range spec.attrs {
+		control.SetAttribute(name, value)
+	}
+	if spec.placeholder != "" {
+		control.SetAttribute("placeholder", spec.placeholder)
+	}
+	if spec.required {
+		control.SetAttribute("required", "")
+		control.SetAttribute("aria-required", "true")
+	}
+
+	label := El("label", Attr("for", ensureID(control)), Text(spec.label))
+	if spec.required {
+		label.AppendChild(El("span",
+			Class("dom-form-required"),
+			Attr("aria-hidden", "true"),
+			Styled(func(s CSSStyleI) { s.Color(formErrorColor).Padding("0 0 0 2px") }),
+			Text("*"),
+		))
+	}
+
+	errorSlot := El("span",
+		Class("dom-form-error"),
+		Attr("aria-live", "polite"),
+		Styled(func(s CSSStyleI) { s.Color(formErrorColor) }),
+	)
+	ensureID(errorSlot)
+	control.SetAriaDescribedBy(errorSlot)
+
+	g.Controls[spec.field] = control
+	g.errorSlots[spec.field] = errorSlot
+
+	return El("div",
+		Class("dom-form-field"),
+		Attr("data-field", spec.name),
+		FlexBox(func(f CSSStyleFlexBoxI) { f.FlexDirection(FlexBoxFlexDirection_Column) }),
+		Styled(func(s CSSStyleI) { s.Padding("4px 0") }),
+		Children(label, control, errorSlot),
+	)
+}
+
+// formIDs names the controls and error slots of generated forms when the id
+// generator of Doc gives none, as labels and descriptions refer to them.
+var formIDs = NewSequentialIDGenerator("dom-form-")
+
+// ensureID returns the id of e, giving it one from formIDs if it has none.
+func ensureID(e ElementI) string {
+	if id := e.ID(); id != "" {
+		return id
+	}
+	id := formIDs.NextID()
+	e.SetID(id)
+	return id
+}
+
+// ShowErrors puts each error in the slot under its field and clears the others.
+func (g *GeneratedFormS) ShowErrors(errs FormErrorsT) {
+	for field, slot := range g.errorSlots {
+		control := g.Controls[field]
+		if err, ok := errs[field]; ok {
+			slot.SetTextContent(err.Error())
+			control.SetAttribute("aria-invalid", "true")
+		} else {
+			slot.SetTextContent("")
+			control.RemoveAttribute("aria-invalid")
+		}
+	}
+}
+
+// OnSubmit reads the form on submit, shows any errors and calls handler.
+func (g *GeneratedFormS) OnSubmit(handler func(errs FormErrorsT)) {
+	g.Binding.OnSubmit(func(errs FormErrorsT) {
+		g.ShowErrors(errs)
+		handler(errs)
+	})
+}
+
+// ErrorSlot returns the element that shows the error of the struct field.
+func (g *GeneratedFormS) ErrorSlot(field string) ElementI {
+	return g.errorSlots[field]
+}
@@ -0,0 +1,144 @@
+package dom
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+type testPlan string
+
+func (testPlan) FormOptions() []string { return []string{"free", "pro"} }
+
+func TestFormControlSpecs(t *testing.T) {
+	type signup struct {
+		Email     string `form:"email,required,type=email" label:"Email address"`
+		Age       int    `form:"age,min=18"`
+		Ratio     float64
+		Subscribe bool
+		Plan      testPlan
+		Tags      []string `form:"tags,options=a|b"`
+		Notes     string   `form:"notes,type=textarea"`
+		Born      time.Time
+	}
+	specs, err := formControlSpecs(reflect.TypeOf(signup{}))
+	if err != nil {
+		t.Fatalf("unexpected error: %+v\n", err)
+	}
+
+	type summary struct {
+		label, tag string
+		typ        InputType
+		multiple   bool
+		options    []string
+	}
+	var found []summary
+	for _, spec := range specs {
+		found = append(found, summary{spec.label, spec.tag, spec.typ, spec.multiple, spec.options})
+	}
+	expected := []summary{
+		{"Email address", "input", InputType_Email, false, nil},
+		{"Age", "input", InputType_Number, false, nil},
+		{"Ratio", "input", InputType_Number, false, nil},
+		{"Subscribe", "input", InputType_Checkbox, false, nil},
+		{"Plan", "select", "", false, []string{"free", "pro"}},
+		{"Tags", "select", "", true, []string{"a", "b"}},
+		{"Notes", "textarea", "", false, nil},
+		{"Born", "input", InputType_Date, false, nil},
+	}
+	if !reflect.DeepEqual(expected, found) {
+		t.Errorf("expected: %+v but found: %+v\n", expected, found)
+	}
+
+	if specs[1].attrs["min"] != "18" || specs[1].attrs["step"] != "1" || specs[2].attrs["step"] != "any" {
+		t.Errorf("unexpected number attributes: %+v %+v\n", specs[1].attrs, specs[2].attrs)
+	}
+}
+
+func TestFormControlSpecsUnsupported(t *testing.T) {
+	type bad struct {
+		Tags []string
+	}
+	if _, err := formControlSpecs(reflect.TypeOf(bad{})); err == nil {
+		t.Errorf("expected an error\n")
+	}
+}
+
+func TestLabelCase(t *testing.T) {
+	if found := labelCase("FirstName"); found != "First name" {
+		t.Errorf("expected: %s but found: %s\n", "First name", found)
+	}
+}
+
+type testSize string
+
+func (*testSize) FormOptions() []string { return []string{"s", "m"} }
+
+func TestFormControlSpecsPointers(t *testing.T) {
+	type profile struct {
+		Nick  *string
+		Age   *int
+		News  *bool
+		Plan  *testPlan
+		Size  testSize
+		Sizes []*testSize
+		Born  *time.Time
+	}
+	specs, err := formControlSpecs(reflect.TypeOf(profile{}))
+	if err != nil {
+		t.Fatalf("unexpected error: %+v\n", err)
+	}
+
+	type summary struct {
+		tag     string
+		typ     InputType
+		options []string
+	}
+	var found []summary
+	for _, spec := range specs {
+		found = append(found, summary{spec.tag, spec.typ, spec.options})
+	}
+	expected := []summary{
+		{"input", InputType_Text, nil},
+		{"input", InputType_Number, nil},
+		{"input", InputType_Checkbox, nil},
+		{"select", "", []string{"free", "pro"}},
+		{"select", "", []string{"s", "m"}},
+		{"select", "", []string{"s", "m"}},
+		{"input", InputType_Date, nil},
+	}
+	if !reflect.DeepEqual(expected, found) {
+		t.Errorf("expected: %+v but found: %+v\n", expected, found)
+	}
+
+	// an interface has no value to list its options, and is not a control
+	type choice struct {
+		Plan FormOptionsI
+	}
+	if _, err := formControlSpecs(reflect.TypeOf(choice{})); err == nil {
+		t.Errorf("expected an error\n")
+	}
+}
+
+func TestGenerateFormWithoutIDGenerator(t *testing.T) {
+	defer Doc.SetIDGenerator(nil)
+	Doc.SetIDGenerator(NewDisabledIDGenerator())
+
+	type signup struct {
+		Email string `form:"email,required"`
+		Age   int
+	}
+	f, err := GenerateForm(&signup{}, "Sign up")
+	if err != nil {
+		t.Fatalf("unexpected error: %+v\n", err)
+	}
+	// labels and error slots refer to their controls by id
+	ids := map[string]bool{}
+	for _, field := range []string{"Email", "Age"} {
+		ids[f.Controls[field].ID()] = true
+		ids[f.ErrorSlot(field).ID()] = true
+	}
+	if len(ids) != 4 || ids[""] {
+		t.Errorf("expected: 4 different ids but found: %+v\n", ids)
+	}
+}
@@ -0,0 +1,303 @@
+package dom
+
+import (
+	"fmt"
+	"image"
+	"math"
+)
+
+/*
+Go value types for geometry, mirroring DOMRect, DOMPoint and DOMMatrix.
+Unlike RectI, which reads a JavaScript object on every call, these are plain
+values that can be compared, copied and computed with. RectI.Rect takes a copy of a DOMRect.
+
+	box := e.GetBoundingClientRect().Rect()
+	if box.Contains(PointT{X: x, Y: y}) { ... }
+	overlay := box.Inset(-4, -4).Intersect(viewport)
+*/
+
+// PointT is a 2D point. https://developer.mozilla.org/en-US/docs/Web/API/DOMPoint
+type PointT struct {
+	X float64
+	Y float64
+}
+
+func (p PointT) Add(q PointT) PointT       { return PointT{X: p.X + q.X, Y: p.Y + q.Y} }
+func (p PointT) Sub(q PointT) PointT       { return PointT{X: p.X - q.X, Y: p.Y - q.Y} }
+func (p PointT) Scale(f float64) PointT    { return PointT{X: p.X * f, Y: p.Y * f} }
+func (p PointT) Distance(q PointT) float64 { return math.Hypot(p.X-q.X, p.Y-q.Y) }
+
+// In reports whether p is inside r.
+func (p PointT) In(r RectT) bool {
+	return r.Contains(p)
+}
+
+// ImagePoint rounds p to an image.Point.
+func (p PointT) ImagePoint() image.Point {
+	return image.Pt(int(math.Round(p.X)), int(math.Round(p.Y)))
+}
+
+func PointFromImage(p image.Point) PointT {
+	return PointT{X: float64(p.X), Y: float64(p.Y)}
+}
+
+// PointFromValue reads a DOMPoint or any object with x and y.
+func PointFromValue(val ValueI) PointT {
+	return PointT{X: val.Get("x").Float(), Y: val.Get("y").Float()}
+}
+
+////
+////
+////
+
+// RectT is a rectangle with its origin at the top left. As with DOMRect, the
+// width and height may be negative, which puts the origin at the right or
+// bottom edge; every method treats such a rectangle the same as its Canon.
+// A rectangle with a zero width or height is empty.
+// https://developer.mozilla.org/en-US/docs/Web/API/DOMRect
+type RectT struct {
+	X      float64
+	Y      float64
+	Width  float64
+	Height float64
+}
+
+// RectFromEdges builds a rectangle from its left, top, right and bottom edges.
+func RectFromEdges(left, top, right, bottom float64) RectT {
+	return RectT{X: left, Y: top, Width: right - left, Height: bottom - top}
+}
+
+// RectFromImage converts an image.Rectangle.
+func RectFromImage(r image.Rectangle) RectT {
+	r = r.Canon()
+	return RectFromEdges(float64(r.Min.X), float64(r.Min.Y), float64(r.Max.X), float64(r.Max.Y))
+}
+
+func (r RectT) Left() float64   { return math.Min(r.X, r.X+r.Width) }
+func (r RectT) Top() float64    { return math.Min(r.Y, r.Y+r.Height) }
+func (r RectT) Right() float64  { return math.Max(r.X, r.X+r.Width) }
+func (r RectT) Bottom() float64 { return math.Max(r.Y, r.Y+r.Height) }
+
+func (r RectT) Min() PointT { return PointT{X: r.Left(), Y: r.Top()} }
+func (r RectT) Max() PointT { return PointT{X: r.Right(), Y: r.Bottom()} }
+
+func (r RectT) Center() PointT {
+	return PointT{X: r.X + r.Width/2, Y: r.Y + r.Height/2}
+}
+
+func (r RectT) Area() float64 {
+	if r.Empty() {
+		return 0
+	}
+	c := r.Canon()
+	return c.Width * c.Height
+}
+
+func (r RectT) Empty() bool {
+	c := r.Canon()
+	return c.Width <= 0 || c.Height <= 0
+}
+
+// Canon returns r with a non-negative width and height, the way DOMRect
+// reports left/top/right/bottom for negative sizes.
+func (r RectT) Canon() RectT {
+	return RectFromEdges(r.Left(), r.Top(), r.Right(), r.Bottom())
+}
+
+// Contains reports whether p is inside r. The right and bottom edges are
+// outside, matching image.Rectangle.
+func (r RectT) Contains(p PointT) bool {
+	return !r.Empty() &&
+		p.X >= r.Left() && p.X < r.Right() &&
+		p.Y >= r.Top() && p.Y < r.Bottom()
+}
+
+// ContainsRect reports whether all of s is inside r. An empty s is inside any rectangle.
+func (r RectT) ContainsRect(s RectT) bool {
+	if s.Empty() {
+		return true
+	}
+	return s.Left() >= r.Left() && s.Right() <= r.Right() &&
+		s.Top() >= r.Top() && s.Bottom() <= r.Bottom()
+}
+
+// Intersects reports whether r and s overlap by a non-zero area.
+func (r RectT) Intersects(s RectT) bool {
+	return !r.Intersect(s).Empty()
+}
+
+// Intersect returns the largest rectangle inside both r and s, or the zero
+// rectangle if they do not overlap.
+func (r RectT) Intersect(s RectT) RectT {
+	ret := RectFromEdges(
+		math.Max(r.Left(), s.Left()),
+		math.Max(r.Top(), s.Top()),
+		math.Min(r.Right(), s.Right()),
+		math.Min(r.Bottom(), s.Bottom()),
+	)
+	// edges that crossed over mean no overlap, not a negative size
+	if ret.Width <= 0 || ret.Height <= 0 {
+		return RectT{}
+	}
+	return ret
+}
+
+// Union returns the smallest rectangle containing both r and s. Empty
+// rectangles are ignored.
+func (r RectT) Union(s RectT) RectT {
+	switch {
+	case r.Empty():
+		return s
+	case s.Empty():
+		return r
+	}
+	return RectFromEdges(
+		math.Min(r.Left(), s.Left()),
+		math.Min(r.Top(), s.Top()),
+		math.Max(r.Right(), s.Right()),
+		math.Max(r.Bottom(), s.Bottom()),
+	)
+}
+
+// Inset moves every edge inwards, by dx horizontally and dy vertically.
+// Negative values grow the rectangle. Edges moved past each other meet in
+// the middle, leaving an empty rectangle.
+func (r RectT) Inset(dx, dy float64) RectT {
+	c := r.Canon()
+	dx = math.Min(dx, c.Width/2)
+	dy = math.Min(dy, c.Height/2)
+	return RectT{X: c.X + dx, Y: c.Y + dy, Width: c.Width - 2*dx, Height: c.Height - 2*dy}
+}
+
+func (r RectT) Translate(dx, dy float64) RectT {
+	return RectT{X: r.X + dx, Y: r.Y + dy, Width: r.Width, Height: r.Height}
+}
+
+// ImageRectangle converts r to an image.Rectangle, rounding outwards so the
+// result covers r.
+func (r RectT) ImageRectangle() image.Rectangle {
+	return image.Rect(
+		int(math.Floor(r.Left())),
+		int(math.Floor(r.Top())),
+		int(math.Ceil(r.Right())),
+		int(math.Ceil(r.Bottom())),
+	)
+}
+
+func (r RectT) String() string {
+	return fmt.Sprintf("(%g,%g)-(%g,%g)", r.Left(), r.Top(), r.Right(), r.Bottom())
+}
+
+////
+////
+////
+
+// MatrixT is a 2D affine transformation, in the same layout as a 2D DOMMatrix:
+//
+//	| A C E |
+//	| B D F |
+//	| 0 0 1 |
+//
+// https://developer.mozilla.org/en-US/docs/Web/API/DOMMatrix
+type MatrixT struct {
+	A, B, C, D, E, F float64
+}
+
+func IdentityMatrix() MatrixT {
+	return MatrixT{A: 1, D: 1}
+}
+
+func TranslateMatrix(tx, ty float64) MatrixT {
+	return MatrixT{A: 1, D: 1, E: tx, F: ty}
+}
+
+func ScaleMatrix(sx, sy float64) MatrixT {
+	return MatrixT{A: sx, D: sy}
+}
+
+// RotateMatrix rotates clockwise on screen, by angle in degrees, like CSS rotate().
+func RotateMatrix(angle float64) MatrixT {
+	sin, cos := math.Sincos(angle * math.Pi / 180)
+	return MatrixT{A: cos, B: sin, C: -sin, D: cos}
+}
+
+// MatrixFromValue reads a DOMMatrix.
+func MatrixFromValue(val ValueI) MatrixT {
+	return MatrixT{
+		A: val.Get("a").Float(),
+		B: val.Get("b").Float(),
+		C: val.Get("c").Float(),
+		D: val.Get("d").Float(),
+		E: val.Get("e").Float(),
+		F: val.Get("f").Float(),
+	}
+}
+
+func (m MatrixT) IsIdentity() bool {
+	return m == IdentityMatrix()
+}
+
+// Multiply returns m × n, which applies n first and then m.
+func (m MatrixT) Multiply(n MatrixT) MatrixT {
+	return MatrixT{
+		A: m.A*n.A + m.C*n.B,
+		B: m.B*n.A + m.D*n.B,
+		C: m.A*n.C + m.C*n.D,
+		D: m.B*n.C + m.D*n.D,
+		E: m.A*n.E + m.C*n.F + m.E,
+		F: m.B*n.E + m.D*n.F + m.F,
+	}
+}
+
+func (m MatrixT) Translate(tx, ty float64) MatrixT { return m.Multiply(TranslateMatrix(tx, ty)) }
+func (m MatrixT) Scale(sx, sy float64) MatrixT     { return m.Multiply(ScaleMatrix(sx, sy)) }
+func (m MatrixT) Rotate(angle float64) MatrixT     { return m.Multiply(RotateMatrix(angle)) }
+
+func (m MatrixT) Determinant() float64 {
+	return m.A*m.D - m.B*m.C
+}
+
+// Inverse returns the inverse of m, and false if m cannot be inverted.
+func (m MatrixT) Inverse() (MatrixT, bool) {
+	det := m.Determinant()
+	if det == 0 {
+		return MatrixT{}, false
+	}
+	return MatrixT{
+		A: m.D / det,
+		B: -m.B / det,
+		C: -m.C / det,
+		D: m.A / det,
+		E: (m.C*m.F - m.D*m.E) / det,
+		F: (m.B*m.E - m.A*m.F) / det,
+	}, true
+}
+
+func (m MatrixT) TransformPoint(p PointT) PointT {
+	return PointT{
+		X: m.A*p.X + m.C*p.Y + m.E,
+		Y: m.B*p.X + m.D*p.Y + m.F,
+	}
+}
+
+// TransformRect returns the bounding box of r after the transformation.
+func (m MatrixT) TransformRect(r RectT) RectT {
+	corners := []PointT{
+		m.TransformPoint(PointT{X: r.Left(), Y: r.Top()}),
+		m.TransformPoint(PointT{X: r.Right(), Y: r.Top()}),
+		m.TransformPoint(PointT{X: r.Left(), Y: r.Bottom()}),
+		m.TransformPoint(PointT{X: r.Right(), Y: r.Bottom()}),
+	}
+	left, top := corners[0].X, corners[0].Y
+	right, bottom := left, top
+	for _, c := range corners[1:] {
+		left, right = math.Min(left, c.X), math.Max(right, c.X)
+		top, bottom = math.Min(top, c.Y), math.Max(bottom, c.Y)
+	}
+	return RectFromEdges(left, top, right, bottom)
+}
+
+// CSS returns the matrix as a CSS transform value.
+func (m MatrixT) CSS() string {
+	return fmt.Sprintf("matrix(%g, %g, %g, %g, %g, %g)", m.A, m.B, m.C, m.D, m.E, m.F)
+}
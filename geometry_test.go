@@ -0,0 +1,147 @@
+package dom
+
+import (
+	"image"
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestRectIntersectUnion(t *testing.T) {
+	a := RectT{X: 0, Y: 0, Width: 10, Height: 10}
+	b := RectT{X: 5, Y: 5, Width: 10, Height: 10}
+
+	expected := RectT{X: 5, Y: 5, Width: 5, Height: 5}
+	if found := a.Intersect(b); !reflect.DeepEqual(expected, found) {
+		t.Errorf("expected: %+v but found: %+v\n", expected, found)
+	}
+
+	expected = RectT{X: 0, Y: 0, Width: 15, Height: 15}
+	if found := a.Union(b); !reflect.DeepEqual(expected, found) {
+		t.Errorf("expected: %+v but found: %+v\n", expected, found)
+	}
+
+	c := RectT{X: 20, Y: 20, Width: 1, Height: 1}
+	if a.Intersects(c) {
+		t.Errorf("expected %v and %v not to intersect\n", a, c)
+	}
+	if found := a.Intersect(c); !found.Empty() {
+		t.Errorf("expected an empty intersection but found: %+v\n", found)
+	}
+	if found := a.Union(RectT{}); !reflect.DeepEqual(a, found) {
+		t.Errorf("expected: %+v but found: %+v\n", a, found)
+	}
+}
+
+func TestRectContains(t *testing.T) {
+	r := RectT{X: 10, Y: 10, Width: 20, Height: 10}
+
+	tests := []struct {
+		p        PointT
+		expected bool
+	}{
+		{PointT{X: 10, Y: 10}, true},
+		{PointT{X: 29.9, Y: 19.9}, true},
+		{PointT{X: 30, Y: 15}, false},
+		{PointT{X: 15, Y: 20}, false},
+		{PointT{X: 9, Y: 15}, false},
+	}
+	for _, test := range tests {
+		if found := r.Contains(test.p); found != test.expected {
+			t.Errorf("%v contains %+v: expected: %v but found: %v\n", r, test.p, test.expected, found)
+		}
+	}
+
+	if !r.ContainsRect(r.Inset(2, 2)) {
+		t.Errorf("expected %v to contain its inset\n", r)
+	}
+	if r.ContainsRect(r.Inset(-1, 0)) {
+		t.Errorf("expected %v not to contain its outset\n", r)
+	}
+}
+
+func TestRectNegativeSize(t *testing.T) {
+	r := RectT{X: 10, Y: 10, Width: -4, Height: -2}
+	expected := RectT{X: 6, Y: 8, Width: 4, Height: 2}
+	if found := r.Canon(); !reflect.DeepEqual(expected, found) {
+		t.Errorf("expected: %+v but found: %+v\n", expected, found)
+	}
+	if r.Empty() || r.Area() != 8 {
+		t.Errorf("expected: area 8 but found: empty %v and area %v\n", r.Empty(), r.Area())
+	}
+
+	// the same as the canonical rectangle everywhere
+	other := RectT{X: 0, Y: 0, Width: 8, Height: 9}
+	if found := r.Intersect(other); !reflect.DeepEqual(RectT{X: 6, Y: 8, Width: 2, Height: 1}, found) {
+		t.Errorf("expected: %+v but found: %+v\n", RectT{X: 6, Y: 8, Width: 2, Height: 1}, found)
+	}
+	if found := r.Union(RectT{}); !reflect.DeepEqual(r, found) {
+		t.Errorf("expected: %+v but found: %+v\n", r, found)
+	}
+	if !r.Contains(PointT{X: 7, Y: 9}) || r.Contains(PointT{X: 11, Y: 11}) {
+		t.Errorf("expected %v to contain only points of %v\n", r, expected)
+	}
+	if found := r.Inset(3, 0); !found.Empty() || found.Width < 0 {
+		t.Errorf("expected: an empty inset but found: %+v\n", found)
+	}
+}
+
+func TestRectImage(t *testing.T) {
+	r := RectT{X: 0.5, Y: 1.25, Width: 10, Height: 2.5}
+	expected := image.Rect(0, 1, 11, 4)
+	if found := r.ImageRectangle(); found != expected {
+		t.Errorf("expected: %v but found: %v\n", expected, found)
+	}
+
+	ir := image.Rect(3, 4, 7, 9)
+	if found := RectFromImage(ir).ImageRectangle(); found != ir {
+		t.Errorf("expected: %v but found: %v\n", ir, found)
+	}
+}
+
+func TestMatrix(t *testing.T) {
+	m := IdentityMatrix().Translate(8, 16).Scale(2, 4)
+	p := m.TransformPoint(PointT{X: 1, Y: 1})
+	expected := PointT{X: 10, Y: 20}
+	if p != expected {
+		t.Errorf("expected: %+v but found: %+v\n", expected, p)
+	}
+
+	inv, ok := m.Inverse()
+	if !ok {
+		t.Fatalf("expected %+v to be invertible\n", m)
+	}
+	if found := inv.TransformPoint(p); found != (PointT{X: 1, Y: 1}) {
+		t.Errorf("expected: %+v but found: %+v\n", PointT{X: 1, Y: 1}, found)
+	}
+	if found := m.Multiply(inv); !found.IsIdentity() {
+		t.Errorf("expected the identity but found: %+v\n", found)
+	}
+
+	if _, ok := ScaleMatrix(0, 1).Inverse(); ok {
+		t.Errorf("expected a zero scale not to be invertible\n")
+	}
+
+	r := RotateMatrix(90).TransformRect(RectT{X: 0, Y: 0, Width: 4, Height: 2})
+	round := func(v float64) float64 { return math.Round(v*1e9) / 1e9 }
+	found := RectT{X: round(r.X), Y: round(r.Y), Width: round(r.Width), Height: round(r.Height)}
+	expectedRect := RectT{X: -2, Y: 0, Width: 2, Height: 4}
+	if !reflect.DeepEqual(expectedRect, found) {
+		t.Errorf("expected: %+v but found: %+v\n", expectedRect, found)
+	}
+}
+
+func TestElementsFromPoint(t *testing.T) {
+	button := fakeNode("button", map[string]any{"id": "save"})
+	span := fakeNode("span", nil)
+	html := fakeNode("html", nil, span, button)
+	doc := documentS{ValueI: html, ElementI: newNode(html)}
+
+	found := doc.ElementsFromPoint(5, 5)
+	if len(found) != 2 || found[0].TagName() != "SPAN" || found[1].ID() != "save" {
+		t.Errorf("expected: span, button#save but found: %+v\n", found)
+	}
+	if _, ok := span.props["id"]; ok {
+		t.Errorf("expected: no id but found: %+v\n", span.props["id"])
+	}
+}
@@ -0,0 +1,507 @@
+package dom
+
+import "time"
+
+// https://developer.mozilla.org/en-US/docs/Web/API/ValidityState
+type ValidityStateI interface {
+	BadInput() bool
+	CustomError() bool
+	PatternMismatch() bool
+	RangeOverflow() bool
+	RangeUnderflow() bool
+	StepMismatch() bool
+	TooLong() bool
+	TooShort() bool
+	TypeMismatch() bool
+	Valid() bool
+	ValueMissing() bool
+}
+
+func NewValidityState(val ValueI) validityStateS {
+	ret := validityStateS{
+		ValueI: val,
+	}
+	return ret
+}
+
+type validityStateS struct {
+	ValueI
+}
+
+var _ ValidityStateI = validityStateS{}
+
+func (s validityStateS) BadInput() bool        { return s.Get("badInput").Bool() }
+func (s validityStateS) CustomError() bool     { return s.Get("customError").Bool() }
+func (s validityStateS) PatternMismatch() bool { return s.Get("patternMismatch").Bool() }
+func (s validityStateS) RangeOverflow() bool   { return s.Get("rangeOverflow").Bool() }
+func (s validityStateS) RangeUnderflow() bool  { return s.Get("rangeUnderflow").Bool() }
+func (s validityStateS) StepMismatch() bool    { return s.Get("stepMismatch").Bool() }
+func (s validityStateS) TooLong() bool         { return s.Get("tooLong").Bool() }
+func (s validityStateS) TooShort() bool        { return s.Get("tooShort").Bool() }
+func (s validityStateS) TypeMismatch() bool    { return s.Get("typeMismatch").Bool() }
+func (s validityStateS) Valid() bool           { return s.Get("valid").Bool() }
+func (s validityStateS) ValueMissing() bool    { return s.Get("valueMissing").Bool() }
+
+////
+////
+////
+
+// FormControlI is what input, select and textarea elements have in common.
+type FormControlI interface {
+	ElementI
+
+	Name() string
+	SetName(string)
+	Value() string
+	SetValue(string)
+	Disabled() bool
+	SetDisabled(bool)
+	Required() bool
+	SetRequired(bool)
+	Form() ElementI                                                // https://developer.mozilla.org/en-US/docs/Web/API/HTMLInputElement/form
+	Validity() ValidityStateI                                      // https://developer.mozilla.org/en-US/docs/Web/API/HTMLInputElement/validity
+	ValidationMessage() string                                     // https://developer.mozilla.org/en-US/docs/Web/API/HTMLInputElement/validationMessage
+	CheckValidity() bool                                           // https://developer.mozilla.org/en-US/docs/Web/API/HTMLInputElement/checkValidity
+	ReportValidity() bool                                          // https://developer.mozilla.org/en-US/docs/Web/API/HTMLInputElement/reportValidity
+	SetCustomValidity(msg string)                                  // https://developer.mozilla.org/en-US/docs/Web/API/HTMLInputElement/setCustomValidity
+	WillValidate() bool                                            // https://developer.mozilla.org/en-US/docs/Web/API/HTMLInputElement/willValidate
+	Labels() []ElementI                                            // https://developer.mozilla.org/en-US/docs/Web/API/HTMLInputElement/labels
+	AutoFocus() bool                                               // https://developer.mozilla.org/en-US/docs/Web/API/HTMLInputElement/autofocus
+	SetAutoFocus(bool)                                             // https://developer.mozilla.org/en-US/docs/Web/API/HTMLInputElement/autofocus
+	OnChange(listener func(e EventI, value string)) EventListenerI // fires when the user commits a change
+	OnInput(listener func(e EventI, value string)) EventListenerI  // fires on every edit
+}
+
+type formControlS struct {
+	ElementI
+}
+
+var _ FormControlI = formControlS{}
+
+func (s formControlS) Name() string       { return s.Underlying().Get("name").String() }
+func (s formControlS) SetName(v string)   { s.Underlying().Set("name", v) }
+func (s formControlS) Value() string      { return s.Underlying().Get("value").String() }
+func (s formControlS) SetValue(v string)  { s.Underlying().Set("value", v) }
+func (s formControlS) Disabled() bool     { return s.Underlying().Get("disabled").Bool() }
+func (s formControlS) SetDisabled(v bool) { s.Underlying().Set("disabled", v) }
+func (s formControlS) Required() bool     { return s.Underlying().Get("required").Bool() }
+func (s formControlS) SetRequired(v bool) { s.Underlying().Set("required", v) }
+
+// Form returns the form the control belongs to, wrapped without being
+// given an id, or nil.
+func (s formControlS) Form() ElementI {
+	return observedNode(s.Underlying().Get("form"))
+}
+
+func (s formControlS) Validity() ValidityStateI {
+	return NewValidityState(s.Underlying().Get("validity"))
+}
+
+func (s formControlS) ValidationMessage() string {
+	return s.Underlying().Get("validationMessage").String()
+}
+
+func (s formControlS) CheckValidity() bool {
+	return s.Underlying().Call("checkValidity").Bool()
+}
+
+func (s formControlS) ReportValidity() bool {
+	return s.Underlying().Call("reportValidity").Bool()
+}
+
+// SetCustomValidity marks the control as invalid with msg. An empty msg clears the error.
+func (s formControlS) SetCustomValidity(msg string) {
+	s.Underlying().Call("setCustomValidity", msg)
+}
+
+func (s formControlS) WillValidate() bool {
+	return s.Underlying().Get("willValidate").Bool()
+}
+
+// Labels returns the labels of the control. They are wrapped without being
+// given ids.
+func (s formControlS) Labels() []ElementI {
+	return observedNodes(s.Underlying().Get("labels"))
+}
+
+func (s formControlS) AutoFocus() bool     { return s.Underlying().Get("autofocus").Bool() }
+func (s formControlS) SetAutoFocus(v bool) { s.Underlying().Set("autofocus", v) }
+
+func (s formControlS) OnChange(listener func(e EventI, value string)) EventListenerI {
+	return s.AddEventListener("change", false, func(e EventI) {
+		listener(e, s.Value())
+	})
+}
+
+func (s formControlS) OnInput(listener func(e EventI, value string)) EventListenerI {
+	return s.AddEventListener("input", false, func(e EventI) {
+		listener(e, s.Value())
+	})
+}
+
+////
+////
+////
+
+/*
+https://developer.mozilla.org/en-US/docs/Web/HTML/Element/input#input_types
+*/
+type InputType string
+
+const (
+	InputType_Button        InputType = "button"
+	InputType_Checkbox      InputType = "checkbox"
+	InputType_Color         InputType = "color"
+	InputType_Date          InputType = "date"
+	InputType_DateTimeLocal InputType = "datetime-local"
+	InputType_Email         InputType = "email"
+	InputType_File          InputType = "file"
+	InputType_Hidden        InputType = "hidden"
+	InputType_Month         InputType = "month"
+	InputType_Number        InputType = "number"
+	InputType_Password      InputType = "password"
+	InputType_Radio         InputType = "radio"
+	InputType_Range         InputType = "range"
+	InputType_Search        InputType = "search"
+	InputType_Submit        InputType = "submit"
+	InputType_Tel           InputType = "tel"
+	InputType_Text          InputType = "text"
+	InputType_Time          InputType = "time"
+	InputType_URL           InputType = "url"
+	InputType_Week          InputType = "week"
+)
+
+// https://developer.mozilla.org/en-US/docs/Web/API/HTMLInputElement
+type InputI interface {
+	FormControlI
+
+	Type() InputType
+	SetType(InputType)
+	Placeholder() string
+	SetPlaceholder(string)
+	Checked() bool // checkbox and radio
+	SetChecked(bool)
+	Indeterminate() bool // checkbox
+	SetIndeterminate(bool)
+	DefaultValue() string
+	SetDefaultValue(string)
+	Min() string
+	SetMin(string)
+	Max() string
+	SetMax(string)
+	Step() string
+	SetStep(string)
+	Pattern() string
+	SetPattern(string)
+	MinLength() int
+	SetMinLength(int)
+	MaxLength() int
+	SetMaxLength(int)
+	ReadOnly() bool
+	SetReadOnly(bool)
+	ValueAsNumber() float64 // NaN when the value is not a number
+	SetValueAsNumber(float64)
+	ValueAsTime() time.Time // for date and time inputs, zero when empty
+	SetValueAsTime(time.Time)
+	Select() // https://developer.mozilla.org/en-US/docs/Web/API/HTMLInputElement/select
+	StepUp(n int)
+	StepDown(n int)
+}
+
+type inputS struct {
+	formControlS
+}
+
+var _ InputI = inputS{}
+
+// NewInput creates an <input> of the given type.
+func NewInput(typ InputType) InputI {
+	ret := AsInput(Doc.CreateElement("input"))
+	ret.SetType(typ)
+	return ret
+}
+
+// AsInput wraps an existing <input> element.
+func AsInput(e ElementI) InputI {
+	return inputS{formControlS{ElementI: e}}
+}
+
+func NewCheckbox(checked bool) InputI {
+	ret := NewInput(InputType_Checkbox)
+	ret.SetChecked(checked)
+	return ret
+}
+
+// NewRadio creates a radio button belonging to the group name.
+func NewRadio(name, value string) InputI {
+	ret := NewInput(InputType_Radio)
+	ret.SetName(name)
+	ret.SetValue(value)
+	return ret
+}
+
+func (s inputS) Type() InputType            { return InputType(s.Underlying().Get("type").String()) }
+func (s inputS) SetType(v InputType)        { s.Underlying().Set("type", string(v)) }
+func (s inputS) Placeholder() string        { return s.Underlying().Get("placeholder").String() }
+func (s inputS) SetPlaceholder(v string)    { s.Underlying().Set("placeholder", v) }
+func (s inputS) Checked() bool              { return s.Underlying().Get("checked").Bool() }
+func (s inputS) SetChecked(v bool)          { s.Underlying().Set("checked", v) }
+func (s inputS) Indeterminate() bool        { return s.Underlying().Get("indeterminate").Bool() }
+func (s inputS) SetIndeterminate(v bool)    { s.Underlying().Set("indeterminate", v) }
+func (s inputS) DefaultValue() string       { return s.Underlying().Get("defaultValue").String() }
+func (s inputS) SetDefaultValue(v string)   { s.Underlying().Set("defaultValue", v) }
+func (s inputS) Min() string                { return s.Underlying().Get("min").String() }
+func (s inputS) SetMin(v string)            { s.Underlying().Set("min", v) }
+func (s inputS) Max() string                { return s.Underlying().Get("max").String() }
+func (s inputS) SetMax(v string)            { s.Underlying().Set("max", v) }
+func (s inputS) Step() string               { return s.Underlying().Get("step").String() }
+func (s inputS) SetStep(v string)           { s.Underlying().Set("step", v) }
+func (s inputS) Pattern() string            { return s.Underlying().Get("pattern").String() }
+func (s inputS) SetPattern(v string)        { s.Underlying().Set("pattern", v) }
+func (s inputS) MinLength() int             { return s.Underlying().Get("minLength").Int() }
+func (s inputS) SetMinLength(v int)         { s.Underlying().Set("minLength", v) }
+func (s inputS) MaxLength() int             { return s.Underlying().Get("maxLength").Int() }
+func (s inputS) SetMaxLength(v int)         { s.Underlying().Set("maxLength", v) }
+func (s inputS) ReadOnly() bool             { return s.Underlying().Get("readOnly").Bool() }
+func (s inputS) SetReadOnly(v bool)         { s.Underlying().Set("readOnly", v) }
+func (s inputS) ValueAsNumber() float64     { return s.Underlying().Get("valueAsNumber").Float() }
+func (s inputS) SetValueAsNumber(v float64) { s.Underlying().Set("valueAsNumber", v) }
+
+func (s inputS) ValueAsTime() time.Time {
+	ms := s.Underlying().Get("valueAsNumber")
+	if ms.IsNaN() {
+		return time.Time{}
+	}
+	return time.UnixMilli(int64(ms.Float())).UTC()
+}
+
+func (s inputS) SetValueAsTime(t time.Time) {
+	if t.IsZero() {
+		s.SetValue("")
+		return
+	}
+	s.Underlying().Set("valueAsNumber", float64(t.UnixMilli()))
+}
+
+func (s inputS) Select() {
+	s.Underlying().Call("select")
+}
+
+func (s inputS) StepUp(n int) {
+	s.Underlying().Call("stepUp", n)
+}
+
+func (s inputS) StepDown(n int) {
+	s.Underlying().Call("stepDown", n)
+}
+
+// RadioGroupValue returns the value of the checked radio button named name
+// inside root, or "" if none is checked.
+func RadioGroupValue(root ElementI, name string) string {
+	for _, e := range radioButtons(root) {
+		radio := AsInput(e)
+		if radio.Name() == name && radio.Checked() {
+			return radio.Value()
+		}
+	}
+	return ""
+}
+
+// SetRadioGroupValue checks the radio button named name inside root whose value is value.
+func SetRadioGroupValue(root ElementI, name, value string) {
+	for _, e := range radioButtons(root) {
+		radio := AsInput(e)
+		if radio.Name() == name {
+			radio.SetChecked(radio.Value() == value)
+		}
+	}
+}
+
+// radioButtons returns the radio buttons inside root without giving them ids.
+func radioButtons(root ElementI) []ElementI {
+	return observedNodes(root.Underlying().Call("querySelectorAll", `input[type="radio"]`))
+}
+
+////
+////
+////
+
+// https://developer.mozilla.org/en-US/docs/Web/API/HTMLTextAreaElement
+type TextAreaI interface {
+	FormControlI
+
+	Placeholder() string
+	SetPlaceholder(string)
+	Rows() int
+	SetRows(int)
+	Cols() int
+	SetCols(int)
+	MinLength() int
+	SetMinLength(int)
+	MaxLength() int
+	SetMaxLength(int)
+	ReadOnly() bool
+	SetReadOnly(bool)
+	Select()
+}
+
+type textAreaS struct {
+	formControlS
+}
+
+var _ TextAreaI = textAreaS{}
+
+func NewTextArea() TextAreaI {
+	return AsTextArea(Doc.CreateElement("textarea"))
+}
+
+// AsTextArea wraps an existing <textarea> element.
+func AsTextArea(e ElementI) TextAreaI {
+	return textAreaS{formControlS{ElementI: e}}
+}
+
+func (s textAreaS) Placeholder() string     { return s.Underlying().Get("placeholder").String() }
+func (s textAreaS) SetPlaceholder(v string) { s.Underlying().Set("placeholder", v) }
+func (s textAreaS) Rows() int               { return s.Underlying().Get("rows").Int() }
+func (s textAreaS) SetRows(v int)           { s.Underlying().Set("rows", v) }
+func (s textAreaS) Cols() int               { return s.Underlying().Get("cols").Int() }
+func (s textAreaS) SetCols(v int)           { s.Underlying().Set("cols", v) }
+func (s textAreaS) MinLength() int          { return s.Underlying().Get("minLength").Int() }
+func (s textAreaS) SetMinLength(v int)      { s.Underlying().Set("minLength", v) }
+func (s textAreaS) MaxLength() int          { return s.Underlying().Get("maxLength").Int() }
+func (s textAreaS) SetMaxLength(v int)      { s.Underlying().Set("maxLength", v) }
+func (s textAreaS) ReadOnly() bool          { return s.Underlying().Get("readOnly").Bool() }
+func (s textAreaS) SetReadOnly(v bool)      { s.Underlying().Set("readOnly", v) }
+
+func (s textAreaS) Select() {
+	s.Underlying().Call("select")
+}
+
+////
+////
+////
+
+// https://developer.mozilla.org/en-US/docs/Web/API/HTMLOptionElement
+type OptionI interface {
+	ElementI
+
+	Value() string
+	SetValue(string)
+	Text() string
+	SetText(string)
+	Selected() bool
+	SetSelected(bool)
+	Disabled() bool
+	SetDisabled(bool)
+	Index() int
+}
+
+type optionS struct {
+	ElementI
+}
+
+var _ OptionI = optionS{}
+
+func NewOption(value, text string) OptionI {
+	ret := AsOption(Doc.CreateElement("option"))
+	ret.SetValue(value)
+	ret.SetText(text)
+	return ret
+}
+
+// AsOption wraps an existing <option> element.
+func AsOption(e ElementI) OptionI {
+	return optionS{ElementI: e}
+}
+
+func (s optionS) Value() string      { return s.Underlying().Get("value").String() }
+func (s optionS) SetValue(v string)  { s.Underlying().Set("value", v) }
+func (s optionS) Text() string       { return s.Underlying().Get("text").String() }
+func (s optionS) SetText(v string)   { s.Underlying().Set("text", v) }
+func (s optionS) Selected() bool     { return s.Underlying().Get("selected").Bool() }
+func (s optionS) SetSelected(v bool) { s.Underlying().Set("selected", v) }
+func (s optionS) Disabled() bool     { return s.Underlying().Get("disabled").Bool() }
+func (s optionS) SetDisabled(v bool) { s.Underlying().Set("disabled", v) }
+func (s optionS) Index() int         { return s.Underlying().Get("index").Int() }
+
+// https://developer.mozilla.org/en-US/docs/Web/API/HTMLSelectElement
+type SelectI interface {
+	FormControlI
+
+	Multiple() bool
+	SetMultiple(bool)
+	Size() int
+	SetSize(int)
+	SelectedIndex() int // -1 when nothing is selected
+	SetSelectedIndex(int)
+	Options() []OptionI
+	SelectedOptions() []OptionI
+	SelectedValues() []string
+	SetSelectedValues(values []string)
+	AddOption(value, text string) OptionI
+}
+
+type selectS struct {
+	formControlS
+}
+
+var _ SelectI = selectS{}
+
+func NewSelect() SelectI {
+	return AsSelect(Doc.CreateElement("select"))
+}
+
+// AsSelect wraps an existing <select> element.
+func AsSelect(e ElementI) SelectI {
+	return selectS{formControlS{ElementI: e}}
+}
+
+func (s selectS) Multiple() bool         { return s.Underlying().Get("multiple").Bool() }
+func (s selectS) SetMultiple(v bool)     { s.Underlying().Set("multiple", v) }
+func (s selectS) Size() int              { return s.Underlying().Get("size").Int() }
+func (s selectS) SetSize(v int)          { s.Underlying().Set("size", v) }
+func (s selectS) SelectedIndex() int     { return s.Underlying().Get("selectedIndex").Int() }
+func (s selectS) SetSelectedIndex(v int) { s.Underlying().Set("selectedIndex", v) }
+
+// Options returns the options of the select. Like SelectedOptions, they are
+// wrapped without being given ids.
+func (s selectS) Options() []OptionI {
+	var out []OptionI
+	for _, e := range observedNodes(s.Underlying().Get("options")) {
+		out = append(out, AsOption(e))
+	}
+	return out
+}
+
+func (s selectS) SelectedOptions() []OptionI {
+	var out []OptionI
+	for _, e := range observedNodes(s.Underlying().Get("selectedOptions")) {
+		out = append(out, AsOption(e))
+	}
+	return out
+}
+
+func (s selectS) SelectedValues() []string {
+	var out []string
+	for _, option := range s.SelectedOptions() {
+		out = append(out, option.Value())
+	}
+	return out
+}
+
+// SetSelectedValues selects exactly the options whose value is in values.
+func (s selectS) SetSelectedValues(values []string) {
+	selected := map[string]bool{}
+	for _, v := range values {
+		selected[v] = true
+	}
+	for _, option := range s.Options() {
+		option.SetSelected(selected[option.Value()])
+	}
+}
+
+func (s selectS) AddOption(value, text string) OptionI {
+	ret := NewOption(value, text)
+	s.AppendChild(ret)
+	return ret
+}
@@ -0,0 +1,101 @@
+package dom
+
+import (
+	"reflect"
+	"testing"
+)
+
+func fakeOption(value string, selected bool) *fakeNodeS {
+	return fakeNode("option", map[string]any{"value": value, "selected": selected})
+}
+
+func TestSelectValues(t *testing.T) {
+	options := []*fakeNodeS{fakeOption("a", false), fakeOption("b", true), fakeOption("c", true)}
+	sel := AsSelect(newNode(fakeNode("select", nil, options...)))
+
+	if got := sel.SelectedValues(); !reflect.DeepEqual([]string{"b", "c"}, got) {
+		t.Errorf("expected: %+v but found: %+v\n", []string{"b", "c"}, got)
+	}
+
+	sel.SetSelectedValues([]string{"a", "c"})
+	if got := sel.SelectedValues(); !reflect.DeepEqual([]string{"a", "c"}, got) {
+		t.Errorf("expected: %+v but found: %+v\n", []string{"a", "c"}, got)
+	}
+
+	// wrapping the options leaves the page's elements as they are
+	for _, option := range options {
+		if _, ok := option.props["id"]; ok {
+			t.Errorf("expected: no id but found: %+v\n", option.props["id"])
+		}
+	}
+}
+
+func TestRadioGroupValue(t *testing.T) {
+	radio := func(name, value string, checked bool) *fakeNodeS {
+		return fakeNode("input", map[string]any{"type": "radio", "name": name, "value": value, "checked": checked})
+	}
+	root := newNode(fakeNode("form", nil,
+		radio("plan", "free", false),
+		radio("plan", "pro", true),
+		radio("size", "large", true),
+		fakeNode("input", map[string]any{"type": "text", "name": "plan", "value": "typed"}),
+	))
+
+	if got := RadioGroupValue(root, "plan"); got != "pro" {
+		t.Errorf("expected: %+v but found: %+v\n", "pro", got)
+	}
+	SetRadioGroupValue(root, "plan", "free")
+	if got := RadioGroupValue(root, "plan"); got != "free" {
+		t.Errorf("expected: %+v but found: %+v\n", "free", got)
+	}
+	if got := RadioGroupValue(root, "size"); got != "large" {
+		t.Errorf("expected: %+v but found: %+v\n", "large", got)
+	}
+	if got := RadioGroupValue(root, "color"); got != "" {
+		t.Errorf("expected: %+v but found: %+v\n", "", got)
+	}
+}
+
+func TestFormControlLabels(t *testing.T) {
+	label := fakeNode("label", map[string]any{"textContent": "Email"})
+	input := AsInput(newNode(fakeNode("input", map[string]any{"labels": []*fakeNodeS{label}})))
+
+	labels := input.Labels()
+	if len(labels) != 1 || labels[0].Underlying() != label {
+		t.Errorf("expected: the email label but found: %+v\n", labels)
+	}
+	if _, ok := label.props["id"]; ok {
+		t.Errorf("expected: no id but found: %+v\n", label.props["id"])
+	}
+	if input.Form() != nil {
+		t.Errorf("expected: no form but found: %+v\n", input.Form())
+	}
+}
+
+func TestFormControlForm(t *testing.T) {
+	form := fakeNode("form", map[string]any{"id": "signup"})
+	input := AsInput(newNode(fakeNode("input", map[string]any{"form": form})))
+
+	if found := input.Form(); found == nil || found.Underlying() != form {
+		t.Errorf("expected: the form but found: %+v\n", found)
+	}
+	// the id other elements refer to with form= is kept
+	if form.props["id"] != "signup" {
+		t.Errorf("expected: %+v but found: %+v\n", "signup", form.props["id"])
+	}
+}
+
+func TestFormControlValue(t *testing.T) {
+	input := AsInput(newNode(fakeNode("input", nil)))
+	input.SetName("email")
+	input.SetValue("a@b.c")
+	input.SetRequired(true)
+	input.SetChecked(true)
+
+	if input.Name() != "email" || input.Value() != "a@b.c" || !input.Required() || !input.Checked() {
+		t.Errorf("expected: the values set but found: %+v, %+v, %+v, %+v\n", input.Name(), input.Value(), input.Required(), input.Checked())
+	}
+	if input.Disabled() {
+		t.Errorf("expected: %+v but found: %+v\n", false, input.Disabled())
+	}
+}
@@ -36,6 +36,19 @@ func (s *TableS) AddRow() *TableRowT {
 	return ret
 }
 
+// AddHeaderCell adds a th cell, which names the cells of its column, or of
+// its row when it starts one.
+func (s *TableRowT) AddHeaderCell() ElementI {
+	cell := s.NewChild("th")
+	s.Columns = append(s.Columns, cell)
+
+	for name, value := range s.Table.DefaultStyling {
+		cell.Style().Set(name, value)
+	}
+
+	return cell
+}
+
 func (s *TableRowT) AddDataCell() ElementI {
 	cell := s.NewChild("td")
 	s.Columns = append(s.Columns, cell)
@@ -0,0 +1,131 @@
+package dom
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"strings"
+)
+
+/*
+Renders html/template output into elements. Event handlers are declared in
+the template with data-on-<event> attributes naming a Go function:
+
+	<button data-on-click="save">Save</button>
+
+	err := RenderTemplate(panel, tmpl, "editor", data, map[string]func(EventI){
+		"save": onSave,
+	})
+*/
+
+// handlerAttrPrefix marks attributes naming an event handler.
+const handlerAttrPrefix = "data-on-"
+
+// ParseHTMLFragment parses html into a DocumentFragment using a <template>
+// element, so scripts in it do not run and nothing is inserted into the
+// document until the fragment is appended. Every node of the result is
+// wrapped, so the fragment can be walked with ChildNodes.
+func ParseHTMLFragment(html string) DocumentFragmentI {
+	tmpl := Doc.CreateElement("template")
+	tmpl.SetInnerHTML(html)
+
+	content := tmpl.Underlying().Get("content")
+	ret := NewDocumentFragment(content)
+	ret.children = wrapChildNodes(content)
+	return ret
+}
+
+// wrapNodeTree wraps a node that was created outside of this package along
+// with its descendants. Unlike NewElement, no ids are given, so the markup
+// stays as it was parsed.
+func wrapNodeTree(val ValueI) ElementI {
+	switch val.Get("nodeType").Int() {
+	case NodeType_Text:
+		return NewTextNode(val)
+	case NodeType_Comment:
+		return NewComment(val)
+	}
+
+	ret := newNode(val)
+	ret.children = wrapChildNodes(val)
+	return ret
+}
+
+func wrapChildNodes(val ValueI) []ElementI {
+	ret := []ElementI{}
+	for _, child := range nodeListToObjects(val.Get("childNodes")) {
+		ret = append(ret, wrapNodeTree(child))
+	}
+	return ret
+}
+
+// WireHandlers adds a listener for every data-on-<event> attribute found in
+// root and its tracked descendants, using the handler named by the
+// attribute's value. Listeners go through AddEventListener, so they are
+// removed with the element. It fails without adding anything if a handler
+// is missing.
+func WireHandlers(root ElementI, handlers map[string]func(EventI)) error {
+	type wiringT struct {
+		e       ElementI
+		typ     string
+		handler func(EventI)
+	}
+	var wirings []wiringT
+
+	var walk func(e ElementI) error
+	walk = func(e ElementI) error {
+		if e.NodeType() == NodeType_Element {
+			for name, value := range e.Attributes() {
+				typ, ok := strings.CutPrefix(name, handlerAttrPrefix)
+				if !ok {
+					continue
+				}
+				handler, ok := handlers[value]
+				if !ok {
+					return fmt.Errorf("no handler named %q for %s on <%s>", value, name, strings.ToLower(e.TagName()))
+				}
+				wirings = append(wirings, wiringT{e: e, typ: typ, handler: handler})
+			}
+		}
+		for _, child := range e.ChildNodes() {
+			if err := walk(child); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if err := walk(root); err != nil {
+		return err
+	}
+
+	for _, w := range wirings {
+		w.e.AddEventListener(w.typ, false, w.handler)
+	}
+	return nil
+}
+
+// RenderTemplate executes the named template (or tmpl itself when name is
+// empty) with data, wires its handlers and replaces the contents of target
+// with the result.
+func RenderTemplate(target ElementI, tmpl *template.Template, name string, data any, handlers map[string]func(EventI)) error {
+	var buf bytes.Buffer
+	var err error
+	if name == "" {
+		err = tmpl.Execute(&buf, data)
+	} else {
+		err = tmpl.ExecuteTemplate(&buf, name, data)
+	}
+	if err != nil {
+		return err
+	}
+
+	frag := ParseHTMLFragment(buf.String())
+	if err := WireHandlers(frag, handlers); err != nil {
+		frag.Remove()
+		return err
+	}
+
+	target.RemoveChildren()
+	target.AppendChild(frag)
+	return nil
+}
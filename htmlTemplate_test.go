@@ -0,0 +1,75 @@
+package dom
+
+import (
+	"html/template"
+	"testing"
+)
+
+func TestWireHandlers(t *testing.T) {
+	input := fakeNode("input", map[string]any{"data-on-input": "edit", "data-on-change": "edit", "name": "title"})
+	button := fakeNode("button", map[string]any{"data-on-click": "save"})
+	rootVal := fakeNode("div", nil, input, button)
+	root := newNode(rootVal)
+	root.AppendChild(newNode(input))
+	root.AppendChild(newNode(button))
+
+	handlers := map[string]func(EventI){"edit": func(EventI) {}, "save": func(EventI) {}}
+	if err := WireHandlers(root, handlers); err != nil {
+		t.Fatalf("unexpected error: %v\n", err)
+	}
+	if len(input.listeners) != 2 || input.listeners["input"] == nil || input.listeners["change"] == nil {
+		t.Errorf("expected: input and change listeners but found: %+v\n", input.listeners)
+	}
+	if len(button.listeners) != 1 || button.listeners["click"] == nil || len(rootVal.listeners) != 0 {
+		t.Errorf("expected: only a click listener on the button but found: %+v and %+v\n", button.listeners, rootVal.listeners)
+	}
+}
+
+func TestWireHandlersMissing(t *testing.T) {
+	input := fakeNode("input", map[string]any{"data-on-input": "edit"})
+	button := fakeNode("button", map[string]any{"data-on-click": "delete"})
+	root := newNode(fakeNode("div", nil, input, button))
+	root.AppendChild(newNode(input))
+	root.AppendChild(newNode(button))
+
+	err := WireHandlers(root, map[string]func(EventI){"edit": func(EventI) {}})
+	expected := `no handler named "delete" for data-on-click on <button>`
+	if err == nil || err.Error() != expected {
+		t.Errorf("expected: %s but found: %v\n", expected, err)
+	}
+	// nothing is wired when a handler is missing
+	if len(input.listeners) != 0 {
+		t.Errorf("expected: no listeners but found: %+v\n", input.listeners)
+	}
+}
+
+func TestRenderTemplateError(t *testing.T) {
+	tmpl := template.Must(template.New("page").Parse(`<p>{{.}}</p>`))
+	target := NewElement(valueS{})
+	if err := RenderTemplate(target, tmpl, "missing", nil, nil); err == nil {
+		t.Errorf("expected an error for a missing template\n")
+	}
+	if err := RenderTemplate(target, tmpl, "", "hello", nil); err != nil {
+		t.Errorf("unexpected error: %v\n", err)
+	}
+	target.Remove()
+}
+
+func TestWrapNodeTree(t *testing.T) {
+	text := fakeNode("", map[string]any{"nodeType": NodeType_Text})
+	item := fakeNode("li", nil, text)
+	list := fakeNode("ul", map[string]any{"id": "todo"}, item)
+
+	root := wrapNodeTree(list)
+	if root.ID() != "todo" || len(root.ChildNodes()) != 1 {
+		t.Fatalf("expected: ul#todo with one child but found: %+v with %+v\n", root.ID(), root.ChildNodes())
+	}
+	child := root.ChildNodes()[0]
+	if children := child.ChildNodes(); child.Underlying() != item || len(children) != 1 || children[0].Underlying() != text {
+		t.Errorf("expected: the li holding the text node but found: %+v\n", children)
+	}
+	// the parsed markup is left as it is
+	if _, ok := item.props["id"]; ok {
+		t.Errorf("expected: no id but found: %+v\n", item.props["id"])
+	}
+}
@@ -0,0 +1,169 @@
+package dom
+
+import (
+	"crypto/rand"
+	"fmt"
+	"sync"
+)
+
+/*
+Element ids. NewElement gives every element it wraps an id from the
+generator of Doc, and a document's CreateElement uses that document's
+generator. The default numbers elements id_000000, id_000001 and so on,
+which collides when two Go programs share a page. Give each program its own
+namespace, or turn ids off, before creating elements:
+
+	Doc.SetIDGenerator(NewNamespacedIDGenerator())
+
+Tests that compare ids can start every case from the same sequence:
+
+	Doc.IDGenerator().Reset()
+*/
+
+// IDGeneratorI hands out the ids given to new elements.
+type IDGeneratorI interface {
+	// NextID returns a new id. An empty id leaves the element's id as it is.
+	NextID() string
+	// Reset starts the sequence over, so tests get the same ids every run.
+	// Generators without a sequence ignore it.
+	Reset()
+}
+
+type sequentialIDGeneratorS struct {
+	mutex   sync.Mutex
+	prefix  string
+	counter int
+}
+
+// NewSequentialIDGenerator numbers ids in order after prefix, such as
+// prefix000000, prefix000001 and so on. The sequence is the same every run.
+func NewSequentialIDGenerator(prefix string) IDGeneratorI {
+	return &sequentialIDGeneratorS{prefix: prefix}
+}
+
+func (g *sequentialIDGeneratorS) NextID() string {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	id := fmt.Sprintf("%s%06d", g.prefix, g.counter)
+	g.counter++
+	return id
+}
+
+func (g *sequentialIDGeneratorS) Reset() {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	g.counter = 0
+}
+
+// NewNamespacedIDGenerator numbers ids in order after a namespace picked at
+// random, such as id_k3x9qa_000000, so programs sharing a page never
+// collide. Reset restarts the numbers but keeps the namespace.
+func NewNamespacedIDGenerator() IDGeneratorI {
+	const letters = "abcdefghijklmnopqrstuvwxyz0123456789"
+	namespace := make([]byte, 6)
+	for i, b := range randomBytes(len(namespace)) {
+		namespace[i] = letters[int(b)%len(letters)]
+	}
+	return NewSequentialIDGenerator("id_" + string(namespace) + "_")
+}
+
+type uuidIDGeneratorS struct{}
+
+// NewUUIDIDGenerator gives every element a random version 4 UUID after
+// "id-", so ids stay unique across programs and page loads. The "id-" keeps
+// them valid in CSS selectors, which reject ids starting with a digit.
+func NewUUIDIDGenerator() IDGeneratorI {
+	return uuidIDGeneratorS{}
+}
+
+func (uuidIDGeneratorS) NextID() string {
+	b := randomBytes(16)
+	b[6] = b[6]&0x0f | 0x40 // version 4
+	b[8] = b[8]&0x3f | 0x80 // RFC 4122 variant
+	return fmt.Sprintf("id-%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
+
+func (uuidIDGeneratorS) Reset() {}
+
+type disabledIDGeneratorS struct{}
+
+// NewDisabledIDGenerator gives no ids, leaving elements as they are.
+func NewDisabledIDGenerator() IDGeneratorI {
+	return disabledIDGeneratorS{}
+}
+
+func (disabledIDGeneratorS) NextID() string { return "" }
+func (disabledIDGeneratorS) Reset()         {}
+
+func randomBytes(n int) []byte {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		panic(fmt.Sprintf("dom: reading random bytes: %v", err))
+	}
+	return b
+}
+
+////
+////
+////
+
+// defaultIDGenerator is used by documents without a generator of their own.
+var defaultIDGenerator = NewSequentialIDGenerator("id_")
+
+// listenerIDs names event listeners. They are only used as keys, so they do
+// not depend on the generator of any document.
+var listenerIDs = NewSequentialIDGenerator("listener_")
+
+// documentIDGeneratorsS holds the generators set with SetIDGenerator by
+// document, so every wrapper of a document uses the same one.
+type documentIDGeneratorsS struct {
+	mutex      sync.Mutex
+	documents  []ValueI
+	generators []IDGeneratorI
+}
+
+var documentIDGenerators documentIDGeneratorsS
+
+func (s *documentIDGeneratorsS) get(doc ValueI) IDGeneratorI {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	for i, d := range s.documents {
+		if d.Equal(doc) {
+			return s.generators[i]
+		}
+	}
+	return defaultIDGenerator
+}
+
+func (s *documentIDGeneratorsS) set(doc ValueI, gen IDGeneratorI) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	for i, d := range s.documents {
+		if d.Equal(doc) {
+			s.generators[i] = gen
+			return
+		}
+	}
+	s.documents = append(s.documents, doc)
+	s.generators = append(s.generators, gen)
+}
+
+func (d *documentS) IDGenerator() IDGeneratorI {
+	return documentIDGenerators.get(d.ValueI)
+}
+
+func (d *documentS) SetIDGenerator(gen IDGeneratorI) {
+	if gen == nil {
+		gen = defaultIDGenerator
+	}
+	documentIDGenerators.set(d.ValueI, gen)
+}
+
+// GetNextID returns the next id from the generator of Doc.
+func GetNextID() string {
+	if Doc == nil {
+		// while Doc itself is created
+		return defaultIDGenerator.NextID()
+	}
+	return Doc.IDGenerator().NextID()
+}
@@ -0,0 +1,56 @@
+package dom
+
+import (
+	"regexp"
+	"testing"
+)
+
+func TestSequentialIDGenerator(t *testing.T) {
+	gen := NewSequentialIDGenerator("app_")
+	gen.NextID()
+	gen.Reset()
+
+	got := []string{gen.NextID(), gen.NextID()}
+	expected := []string{"app_000000", "app_000001"}
+	if got[0] != expected[0] || got[1] != expected[1] {
+		t.Errorf("expected: %+v but found: %+v\n", expected, got)
+	}
+}
+
+func TestRandomIDGenerators(t *testing.T) {
+	namespaced := regexp.MustCompile(`^id_[a-z0-9]{6}_000000$`)
+	first, second := NewNamespacedIDGenerator().NextID(), NewNamespacedIDGenerator().NextID()
+	if !namespaced.MatchString(first) || first == second {
+		t.Errorf("expected: two different namespaced ids but found: %+v and %+v\n", first, second)
+	}
+
+	uuid := regexp.MustCompile(`^id-[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+	gen := NewUUIDIDGenerator()
+	first, second = gen.NextID(), gen.NextID()
+	if !uuid.MatchString(first) || first == second {
+		t.Errorf("expected: two different UUID ids but found: %+v and %+v\n", first, second)
+	}
+}
+
+func TestDocumentIDGenerator(t *testing.T) {
+	defer Doc.SetIDGenerator(nil)
+
+	Doc.SetIDGenerator(NewSequentialIDGenerator("app_"))
+	// a new wrapper of the same document shares its generator
+	if got := Window.Document().IDGenerator().NextID(); got != "app_000000" {
+		t.Errorf("expected: %+v but found: %+v\n", "app_000000", got)
+	}
+	if got := NewElement(valueS{}).ID(); got != "app_000001" {
+		t.Errorf("expected: %+v but found: %+v\n", "app_000001", got)
+	}
+
+	Doc.SetIDGenerator(NewDisabledIDGenerator())
+	e := NewElement(valueS{})
+	if e.ID() != "" {
+		t.Errorf("expected: %+v but found: %+v\n", "", e.ID())
+	}
+	e.SetID("chosen")
+	if e.ID() != "chosen" {
+		t.Errorf("expected: %+v but found: %+v\n", "chosen", e.ID())
+	}
+}
@@ -0,0 +1,243 @@
+package dom
+
+import "sync"
+
+/*
+Reports when elements enter or leave the viewport, or another scrolling
+element, instead of checking positions on every scroll event:
+
+	obs := NewIntersectionObserver(IntersectionObserverInitT{RootMargin: "200px"}, func(entries []IntersectionEntryT) {
+		for _, entry := range entries {
+			if entry.IsIntersecting { ... }
+		}
+	})
+	obs.Observe(card)
+
+LazyLoadImages and WatchSentinel cover the common uses.
+*/
+
+// IntersectionObserverInitT says what counts as visible. The zero value
+// reports any part of the target becoming visible in the viewport.
+// https://developer.mozilla.org/en-US/docs/Web/API/IntersectionObserver/IntersectionObserver#options
+type IntersectionObserverInitT struct {
+	Root       ElementI  // the scrolling ancestor to check against, nil for the viewport
+	RootMargin string    // CSS margin around the root, such as "200px 0px"; negative values shrink it
+	Thresholds []float64 // visible ratios, from 0 to 1, that trigger the callback when crossed
+}
+
+func (o IntersectionObserverInitT) toMap() map[string]any {
+	m := map[string]any{}
+	if o.Root != nil {
+		m["root"] = o.Root.Underlying()
+	}
+	if o.RootMargin != "" {
+		m["rootMargin"] = o.RootMargin
+	}
+	if o.Thresholds != nil {
+		thresholds := make([]any, 0, len(o.Thresholds))
+		for _, t := range o.Thresholds {
+			thresholds = append(thresholds, t)
+		}
+		m["threshold"] = thresholds
+	}
+	return m
+}
+
+// IntersectionEntryT describes a change in how much of a target is visible.
+// https://developer.mozilla.org/en-US/docs/Web/API/IntersectionObserverEntry
+type IntersectionEntryT struct {
+	Target             ElementI
+	IsIntersecting     bool
+	IntersectionRatio  float64 // visible part of the target, from 0 to 1
+	BoundingClientRect RectI   // of the target
+	IntersectionRect   RectI   // visible part of the target
+	RootBounds         RectI   // nil for a viewport in another origin
+	Time               float64 // milliseconds since the page loaded
+}
+
+////
+////
+////
+
+// https://developer.mozilla.org/en-US/docs/Web/API/IntersectionObserver
+type IntersectionObserverI interface {
+	Underlying() ValueI
+
+	Observe(target ElementI)   // https://developer.mozilla.org/en-US/docs/Web/API/IntersectionObserver/observe
+	Unobserve(target ElementI) // https://developer.mozilla.org/en-US/docs/Web/API/IntersectionObserver/unobserve
+
+	// TakeRecords returns the entries not yet delivered and removes them from the queue.
+	TakeRecords() []IntersectionEntryT // https://developer.mozilla.org/en-US/docs/Web/API/IntersectionObserver/takeRecords
+
+	// Disconnect stops observing every target and releases the JavaScript
+	// callback. The observer cannot be used again.
+	Disconnect() // https://developer.mozilla.org/en-US/docs/Web/API/IntersectionObserver/disconnect
+}
+
+type intersectionObserverS struct {
+	ValueI
+	fn    funcS
+	queue *deliveryQueueS[[]IntersectionEntryT]
+	once  sync.Once
+
+	targets observedTargetsS
+}
+
+var _ IntersectionObserverI = &intersectionObserverS{}
+
+// NewIntersectionObserver creates an observer that calls callback with the
+// targets whose visibility changed. Every target is reported once when it is
+// first observed. Entries are delivered in order on a goroutine of their own.
+func NewIntersectionObserver(opts IntersectionObserverInitT, callback func(entries []IntersectionEntryT)) IntersectionObserverI {
+	ret := &intersectionObserverS{
+		queue: newDeliveryQueue(callback, nil),
+	}
+	ret.fn = NewFuncForJavascript(func(this ValueI, args []ValueI) any {
+		ret.queue.push(ret.newEntries(args[0]))
+		return nil
+	})
+	ret.ValueI = Window.Underlying().Get("IntersectionObserver").New(ret.fn, opts.toMap())
+	return ret
+}
+
+func (o *intersectionObserverS) newEntries(list ValueI) []IntersectionEntryT {
+	var out []IntersectionEntryT
+	for _, val := range arrayToObjects(list) {
+		entry := IntersectionEntryT{
+			Target:             o.targets.find(val.Get("target")),
+			IsIntersecting:     val.Get("isIntersecting").Bool(),
+			IntersectionRatio:  val.Get("intersectionRatio").Float(),
+			BoundingClientRect: NewRect(val.Get("boundingClientRect")),
+			IntersectionRect:   NewRect(val.Get("intersectionRect")),
+			Time:               val.Get("time").Float(),
+		}
+		if bounds := val.Get("rootBounds"); !bounds.IsNull() && !bounds.IsUndefined() {
+			entry.RootBounds = NewRect(bounds)
+		}
+		out = append(out, entry)
+	}
+	return out
+}
+
+func (o *intersectionObserverS) Underlying() ValueI {
+	return o.ValueI
+}
+
+func (o *intersectionObserverS) Observe(target ElementI) {
+	o.targets.add(target, func() { o.Unobserve(target) })
+	o.Call("observe", target.Underlying())
+}
+
+func (o *intersectionObserverS) Unobserve(target ElementI) {
+	o.Call("unobserve", target.Underlying())
+	o.targets.remove(target)
+}
+
+func (o *intersectionObserverS) TakeRecords() []IntersectionEntryT {
+	return o.newEntries(o.Call("takeRecords"))
+}
+
+func (o *intersectionObserverS) Disconnect() {
+	o.once.Do(func() {
+		o.Call("disconnect")
+		o.fn.Release()
+		o.queue.close()
+		o.targets.clear()
+	})
+}
+
+////
+////
+////
+
+// LazyLoadImages loads images once they come within rootMargin of the
+// viewport. The images are expected to hold their source in data-src, and
+// optionally data-srcset, instead of src:
+//
+//	<img data-src="/photos/1.jpg" alt="...">
+//
+//	LazyLoadImages(feed.QuerySelectorAll("img[data-src]"), "200px")
+//
+// The observer disconnects itself once every image is loaded or removed.
+func LazyLoadImages(images []ElementI, rootMargin string) IntersectionObserverI {
+	var obs IntersectionObserverI
+	var mutex sync.Mutex
+	remaining := map[ElementI]func(){} // cancels the OnRemove of each image not loaded yet
+
+	// finish forgets img and disconnects once no image is left
+	finish := func(img ElementI) {
+		mutex.Lock()
+		cancel, ok := remaining[img]
+		delete(remaining, img)
+		done := ok && len(remaining) == 0
+		mutex.Unlock()
+		if !ok {
+			return
+		}
+		cancel()
+		if done {
+			obs.Disconnect()
+		}
+	}
+
+	obs = NewIntersectionObserver(IntersectionObserverInitT{RootMargin: rootMargin}, func(entries []IntersectionEntryT) {
+		for _, entry := range entries {
+			if !entry.IsIntersecting {
+				continue
+			}
+			obs.Unobserve(entry.Target)
+			loadLazyImage(entry.Target)
+			finish(entry.Target)
+		}
+	})
+	if len(images) == 0 {
+		obs.Disconnect()
+		return obs
+	}
+
+	mutex.Lock()
+	for _, img := range images {
+		remaining[img] = img.OnRemove(func() { finish(img) })
+	}
+	mutex.Unlock()
+	for _, img := range images {
+		obs.Observe(img)
+	}
+	return obs
+}
+
+// loadLazyImage moves data-src and data-srcset to src and srcset.
+func loadLazyImage(img ElementI) {
+	if img.HasAttribute("data-srcset") {
+		img.SetAttribute("srcset", img.GetAttribute("data-srcset"))
+		img.RemoveAttribute("data-srcset")
+	}
+	if img.HasAttribute("data-src") {
+		img.SetAttribute("src", img.GetAttribute("data-src"))
+		img.RemoveAttribute("data-src")
+	}
+}
+
+// WatchSentinel calls onVisible every time sentinel comes within rootMargin
+// of the viewport. Put the sentinel after the last item of a list to load
+// more items as the user scrolls, keeping it at the end as items are added:
+//
+//	obs := WatchSentinel(sentinel, "400px", func() {
+//		list.InsertBefore(nextPage(), sentinel)
+//	})
+//
+// onVisible is only called again once the sentinel has left and come back,
+// so each call should add enough to push the sentinel out of view.
+// Disconnect the returned observer to stop. It is disconnected when the
+// sentinel is removed.
+func WatchSentinel(sentinel ElementI, rootMargin string, onVisible func()) IntersectionObserverI {
+	ret := NewIntersectionObserver(IntersectionObserverInitT{RootMargin: rootMargin}, func(entries []IntersectionEntryT) {
+		// only the latest state matters when several changes are reported at once
+		if entries[len(entries)-1].IsIntersecting {
+			onVisible()
+		}
+	})
+	ret.Observe(sentinel)
+	sentinel.OnRemove(ret.Disconnect)
+	return ret
+}
@@ -0,0 +1,60 @@
+package dom
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestIntersectionObserverInit(t *testing.T) {
+	root := Doc.CreateElement("div")
+
+	tests := []struct {
+		opts     IntersectionObserverInitT
+		expected map[string]any
+	}{
+		{
+			IntersectionObserverInitT{},
+			map[string]any{},
+		},
+		{
+			IntersectionObserverInitT{Root: root, RootMargin: "200px 0px", Thresholds: []float64{0, 0.5, 1}},
+			map[string]any{"root": root.Underlying(), "rootMargin": "200px 0px", "threshold": []any{0.0, 0.5, 1.0}},
+		},
+	}
+	for _, test := range tests {
+		if found := test.opts.toMap(); !reflect.DeepEqual(test.expected, found) {
+			t.Errorf("expected: %+v but found: %+v\n", test.expected, found)
+		}
+	}
+}
+
+// observerClosed reports whether obs was disconnected.
+func observerClosed(obs IntersectionObserverI) bool {
+	q := obs.(*intersectionObserverS).queue
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+	return q.closed
+}
+
+func TestLazyLoadImagesEmpty(t *testing.T) {
+	obs := LazyLoadImages(nil, "")
+	if !observerClosed(obs) {
+		t.Errorf("expected the observer to be disconnected\n")
+	}
+	// already disconnected, so a second call must not release anything twice
+	obs.Disconnect()
+}
+
+func TestLazyLoadImagesRemoved(t *testing.T) {
+	first, second := newNode(fakeNode("img", nil)), newNode(fakeNode("img", nil))
+	obs := LazyLoadImages([]ElementI{first, second}, "")
+
+	first.Remove()
+	if observerClosed(obs) {
+		t.Errorf("expected the observer to wait for the second image\n")
+	}
+	second.Remove()
+	if !observerClosed(obs) {
+		t.Errorf("expected the observer to be disconnected\n")
+	}
+}
@@ -0,0 +1,112 @@
+package dom
+
+import (
+	"context"
+	"sync"
+)
+
+/*
+ListenerGroupS removes a set of listeners together, such as everything a
+view added to Window and Doc, which nothing else removes:
+
+	group := NewListenerGroupContext(routeCtx)
+	group.Add(Window, "resize", onResize)
+	group.Add(Doc, "keydown", onKey)
+	group.Track(panel, panel.OnScroll(onScroll))
+
+	// later, or when routeCtx is done
+	group.Release()
+*/
+
+type groupListenerT struct {
+	target   EventTargetI
+	listener EventListenerI
+}
+
+type ListenerGroupS struct {
+	mutex     sync.Mutex
+	listeners []groupListenerT
+	released  bool
+}
+
+func NewListenerGroup() *ListenerGroupS {
+	return &ListenerGroupS{}
+}
+
+// NewListenerGroupContext returns a group that is released when ctx is done.
+func NewListenerGroupContext(ctx context.Context) *ListenerGroupS {
+	ret := NewListenerGroup()
+	context.AfterFunc(ctx, ret.Release)
+	return ret
+}
+
+// Add adds a listener to target and records it in the group.
+func (g *ListenerGroupS) Add(target EventTargetI, typ string, listener func(EventI)) EventListenerI {
+	return g.Track(target, target.AddEventListener(typ, false, listener))
+}
+
+// AddWithOptions is Add with ListenerOptionsT.
+func (g *ListenerGroupS) AddWithOptions(target EventTargetI, typ string, opts ListenerOptionsT, listener func(EventI)) EventListenerI {
+	return g.Track(target, target.AddEventListenerWithOptions(typ, opts, listener))
+}
+
+// On adds a delegated listener to container and records it in the group.
+func (g *ListenerGroupS) On(container ElementI, typ, selector string, handler func(ev EventI, matched ElementI)) EventListenerI {
+	return g.Track(container, container.On(typ, selector, handler))
+}
+
+// Track records a listener that was already added to target, such as one
+// returned by OnScroll. Once the group is released, listeners are removed
+// as soon as they are tracked.
+func (g *ListenerGroupS) Track(target EventTargetI, listener EventListenerI) EventListenerI {
+	g.mutex.Lock()
+	released := g.released
+	if !released {
+		g.listeners = append(g.listeners, groupListenerT{target: target, listener: listener})
+	}
+	g.mutex.Unlock()
+
+	if released {
+		target.RemoveEventListener(listener)
+	}
+	return listener
+}
+
+// Remove removes one listener of the group.
+func (g *ListenerGroupS) Remove(listener EventListenerI) {
+	g.mutex.Lock()
+	var target EventTargetI
+	for i, l := range g.listeners {
+		if l.listener.GetID() == listener.GetID() {
+			target = l.target
+			g.listeners = append(g.listeners[:i], g.listeners[i+1:]...)
+			break
+		}
+	}
+	g.mutex.Unlock()
+
+	if target != nil {
+		target.RemoveEventListener(listener)
+	}
+}
+
+// Len returns how many listeners the group holds.
+func (g *ListenerGroupS) Len() int {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	return len(g.listeners)
+}
+
+// Release removes every listener of the group and releases their
+// functions. It can be called more than once.
+func (g *ListenerGroupS) Release() {
+	g.mutex.Lock()
+	listeners := g.listeners
+	g.listeners = nil
+	g.released = true
+	g.mutex.Unlock()
+
+	for _, l := range listeners {
+		l.target.RemoveEventListener(l.listener)
+	}
+}
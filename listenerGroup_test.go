@@ -0,0 +1,113 @@
+package dom
+
+import (
+	"context"
+	"sync"
+	"testing"
+)
+
+// countingTargetS records which listeners were removed.
+type countingTargetS struct {
+	EventTargetI
+	mutex   sync.Mutex
+	removed []string
+}
+
+func (c *countingTargetS) AddEventListener(typ string, useCapture bool, listener func(EventI)) EventListenerI {
+	return NewEventListener(funcS{}, typ, useCapture)
+}
+
+func (c *countingTargetS) RemoveEventListener(listener EventListenerI) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.removed = append(c.removed, listener.GetID())
+}
+
+func (c *countingTargetS) removedCount() int {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return len(c.removed)
+}
+
+func TestListenerGroupRelease(t *testing.T) {
+	target := &countingTargetS{}
+	group := NewListenerGroup()
+
+	first := group.Add(target, "resize", func(EventI) {})
+	group.Add(target, "keydown", func(EventI) {})
+	group.Add(target, "keyup", func(EventI) {})
+
+	group.Remove(first)
+	if group.Len() != 2 || target.removedCount() != 1 {
+		t.Errorf("expected: 2 held and 1 removed but found: %d held and %d removed\n", group.Len(), target.removedCount())
+	}
+
+	group.Release()
+	group.Release()
+	if group.Len() != 0 || target.removedCount() != 3 {
+		t.Errorf("expected: 0 held and 3 removed but found: %d held and %d removed\n", group.Len(), target.removedCount())
+	}
+
+	// adding to a released group removes the listener straight away
+	group.Add(target, "click", func(EventI) {})
+	if group.Len() != 0 || target.removedCount() != 4 {
+		t.Errorf("expected: 0 held and 4 removed but found: %d held and %d removed\n", group.Len(), target.removedCount())
+	}
+}
+
+// signallingTargetS reports each removal on a channel.
+type signallingTargetS struct {
+	countingTargetS
+	removed chan struct{}
+}
+
+func (s *signallingTargetS) RemoveEventListener(listener EventListenerI) {
+	s.removed <- struct{}{}
+}
+
+func TestListenerGroupContext(t *testing.T) {
+	target := &signallingTargetS{removed: make(chan struct{}, 1)}
+	ctx, cancel := context.WithCancel(context.Background())
+	group := NewListenerGroupContext(ctx)
+	group.Add(target, "resize", func(EventI) {})
+
+	cancel()
+	<-target.removed
+	if group.Len() != 0 {
+		t.Errorf("expected: 0 but found: %d\n", group.Len())
+	}
+}
+
+func TestListenerGroupOutlivesElement(t *testing.T) {
+	val := fakeNode("div", nil)
+	panel := newNode(val)
+	group := NewListenerGroup()
+	group.Add(panel, "click", func(EventI) {})
+	group.Track(panel, panel.OnScroll(func(ScrollInfoT) {}))
+
+	panel.Remove()
+	group.Release()
+	if removed := val.count("removeEventListener"); removed != 2 {
+		t.Errorf("expected: %+v but found: %+v\n", 2, removed)
+	}
+}
+
+func TestRemoveListenerThroughAnotherWrapper(t *testing.T) {
+	val := fakeNode("button", nil)
+	added := newNode(val)
+	listener := added.AddEventListener("click", false, func(EventI) {})
+
+	// such as the wrapper of an event target or a QuerySelector result
+	found := newNode(val)
+	found.RemoveEventListener(listener)
+	if len(val.listeners) != 0 {
+		t.Errorf("expected: no listeners but found: %+v\n", val.listeners)
+	}
+
+	// the listener is removed in JavaScript only once
+	added.Remove()
+	found.RemoveEventListener(listener)
+	if removed := val.count("removeEventListener"); removed != 1 {
+		t.Errorf("expected: %+v but found: %+v\n", 1, removed)
+	}
+}
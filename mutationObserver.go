@@ -0,0 +1,259 @@
+package dom
+
+import (
+	"errors"
+	"sync"
+)
+
+/*
+Watches for changes to the DOM, including ones made by code outside of Go:
+
+	obs := NewMutationObserver(func(records []MutationRecordT) {
+		for _, r := range records {
+			for _, added := range r.AddedNodes { ... }
+		}
+	})
+	err := obs.Observe(widget, MutationObserverInitT{ChildList: true, Subtree: true})
+	defer obs.Disconnect()
+
+Nodes in the records are wrapped without being changed, so no ids are
+added to elements that belong to other code.
+*/
+
+// https://developer.mozilla.org/en-US/docs/Web/API/MutationRecord/type
+type MutationType string
+
+const (
+	MutationType_ChildList     MutationType = "childList"
+	MutationType_Attributes    MutationType = "attributes"
+	MutationType_CharacterData MutationType = "characterData"
+)
+
+// MutationObserverInitT says which changes to report. At least one of
+// ChildList, Attributes and CharacterData must be set. Attributes is
+// implied by AttributeOldValue and AttributeFilter, and CharacterData by
+// CharacterDataOldValue.
+// https://developer.mozilla.org/en-US/docs/Web/API/MutationObserver/observe#options
+type MutationObserverInitT struct {
+	ChildList             bool
+	Attributes            bool
+	AttributeFilter       []string // only report these attributes
+	AttributeOldValue     bool
+	CharacterData         bool
+	CharacterDataOldValue bool
+	Subtree               bool // also watch the target's descendants
+}
+
+var errNoMutationTypes = errors.New("mutation observer: one of ChildList, Attributes or CharacterData must be set")
+
+func (o MutationObserverInitT) toMap() (map[string]any, error) {
+	attributes := o.Attributes || o.AttributeOldValue || o.AttributeFilter != nil
+	characterData := o.CharacterData || o.CharacterDataOldValue
+	if !o.ChildList && !attributes && !characterData {
+		return nil, errNoMutationTypes
+	}
+
+	// the browser rejects explicit false values that conflict with the
+	// old value and filter options, so only set what is wanted
+	m := map[string]any{}
+	if o.ChildList {
+		m["childList"] = true
+	}
+	if attributes {
+		m["attributes"] = true
+	}
+	if o.AttributeFilter != nil {
+		filter := make([]any, 0, len(o.AttributeFilter))
+		for _, name := range o.AttributeFilter {
+			filter = append(filter, name)
+		}
+		m["attributeFilter"] = filter
+	}
+	if o.AttributeOldValue {
+		m["attributeOldValue"] = true
+	}
+	if characterData {
+		m["characterData"] = true
+	}
+	if o.CharacterDataOldValue {
+		m["characterDataOldValue"] = true
+	}
+	if o.Subtree {
+		m["subtree"] = true
+	}
+	return m, nil
+}
+
+// MutationRecordT describes one change.
+// https://developer.mozilla.org/en-US/docs/Web/API/MutationRecord
+type MutationRecordT struct {
+	Type               MutationType
+	Target             ElementI
+	AddedNodes         []ElementI
+	RemovedNodes       []ElementI
+	PreviousSibling    ElementI // of the added or removed nodes, nil if there is none
+	NextSibling        ElementI
+	AttributeName      string
+	AttributeNamespace string
+	OldValue           string // only when asked for with AttributeOldValue or CharacterDataOldValue
+}
+
+func newMutationRecord(val ValueI) MutationRecordT {
+	ret := MutationRecordT{
+		Type:               MutationType(val.Get("type").String()),
+		Target:             observedNode(val.Get("target")),
+		PreviousSibling:    observedNode(val.Get("previousSibling")),
+		NextSibling:        observedNode(val.Get("nextSibling")),
+		AttributeName:      val.Get("attributeName").String(),
+		AttributeNamespace: val.Get("attributeNamespace").String(),
+		OldValue:           val.Get("oldValue").String(),
+	}
+	for _, node := range nodeListToObjects(val.Get("addedNodes")) {
+		ret.AddedNodes = append(ret.AddedNodes, observedNode(node))
+	}
+	for _, node := range nodeListToObjects(val.Get("removedNodes")) {
+		ret.RemovedNodes = append(ret.RemovedNodes, observedNode(node))
+	}
+	return ret
+}
+
+func newMutationRecords(list ValueI) []MutationRecordT {
+	var out []MutationRecordT
+	for _, val := range arrayToObjects(list) {
+		out = append(out, newMutationRecord(val))
+	}
+	return out
+}
+
+////
+////
+////
+
+// https://developer.mozilla.org/en-US/docs/Web/API/MutationObserver
+type MutationObserverI interface {
+	Underlying() ValueI
+
+	// Observe starts reporting changes to target. Observing a target again
+	// replaces its options.
+	Observe(target ElementI, opts MutationObserverInitT) error // https://developer.mozilla.org/en-US/docs/Web/API/MutationObserver/observe
+
+	// TakeRecords returns the changes not yet delivered and removes them from the queue.
+	TakeRecords() []MutationRecordT // https://developer.mozilla.org/en-US/docs/Web/API/MutationObserver/takeRecords
+
+	// Disconnect stops observing every target and releases the JavaScript
+	// callback. Changes the browser has not reported yet are dropped, but
+	// batches already reported are still delivered. The observer cannot be
+	// used again.
+	Disconnect() // https://developer.mozilla.org/en-US/docs/Web/API/MutationObserver/disconnect
+}
+
+type mutationObserverS struct {
+	ValueI
+	fn    funcS
+	queue *deliveryQueueS[[]MutationRecordT]
+	once  sync.Once
+
+	// disconnected, if set, is closed by Disconnect to stop waiting for
+	// the consumer of NewMutationObserverChan.
+	disconnected chan struct{}
+
+	mutex        sync.Mutex
+	cancelRemove func() // of the OnRemove set by ObserveMutations
+}
+
+var _ MutationObserverI = &mutationObserverS{}
+
+// NewMutationObserver creates an observer that calls callback with each
+// batch of changes. Batches are delivered in order on a goroutine of their
+// own, so a slow callback delays later batches but never the browser.
+func NewMutationObserver(callback func(records []MutationRecordT)) MutationObserverI {
+	return newMutationObserver(callback, nil)
+}
+
+// mutationChanBuffer is how many batches NewMutationObserverChan holds for
+// a consumer that is not reading.
+const mutationChanBuffer = 16
+
+// NewMutationObserverChan is NewMutationObserver delivering batches to the
+// returned channel, which is closed once the observer is disconnected. The
+// channel holds a few batches; after that delivery waits for the consumer.
+// Batches still waiting when the observer is disconnected are dropped, so
+// a consumer that stops reading never keeps the observer's goroutine alive.
+func NewMutationObserverChan() (MutationObserverI, <-chan []MutationRecordT) {
+	ch := make(chan []MutationRecordT, mutationChanBuffer)
+	disconnected := make(chan struct{})
+	ret := newMutationObserver(
+		func(records []MutationRecordT) {
+			select {
+			case ch <- records:
+			case <-disconnected:
+			}
+		},
+		func() { close(ch) },
+	)
+	ret.disconnected = disconnected
+	return ret, ch
+}
+
+// ObserveMutations creates an observer and starts observing target. The
+// observer is disconnected when target is removed.
+func ObserveMutations(target ElementI, opts MutationObserverInitT, callback func(records []MutationRecordT)) (MutationObserverI, error) {
+	ret := newMutationObserver(callback, nil)
+	if err := ret.Observe(target, opts); err != nil {
+		ret.Disconnect()
+		return nil, err
+	}
+	cancelRemove := target.OnRemove(ret.Disconnect)
+	ret.mutex.Lock()
+	ret.cancelRemove = cancelRemove
+	ret.mutex.Unlock()
+	return ret, nil
+}
+
+func newMutationObserver(callback func([]MutationRecordT), done func()) *mutationObserverS {
+	ret := &mutationObserverS{
+		queue: newDeliveryQueue(callback, done),
+	}
+	ret.fn = NewFuncForJavascript(func(this ValueI, args []ValueI) any {
+		ret.queue.push(newMutationRecords(args[0]))
+		return nil
+	})
+	ret.ValueI = Window.Underlying().Get("MutationObserver").New(ret.fn)
+	return ret
+}
+
+func (m *mutationObserverS) Underlying() ValueI {
+	return m.ValueI
+}
+
+func (m *mutationObserverS) Observe(target ElementI, opts MutationObserverInitT) error {
+	init, err := opts.toMap()
+	if err != nil {
+		return err
+	}
+	m.Call("observe", target.Underlying(), init)
+	return nil
+}
+
+func (m *mutationObserverS) TakeRecords() []MutationRecordT {
+	return newMutationRecords(m.Call("takeRecords"))
+}
+
+func (m *mutationObserverS) Disconnect() {
+	m.once.Do(func() {
+		m.Call("disconnect")
+		m.fn.Release()
+		m.queue.close()
+		if m.disconnected != nil {
+			close(m.disconnected)
+		}
+
+		m.mutex.Lock()
+		cancelRemove := m.cancelRemove
+		m.mutex.Unlock()
+		// nil when the target was removed while the observer was set up
+		if cancelRemove != nil {
+			cancelRemove()
+		}
+	})
+}
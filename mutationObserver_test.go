@@ -0,0 +1,101 @@
+package dom
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestMutationObserverInit(t *testing.T) {
+	tests := []struct {
+		opts     MutationObserverInitT
+		expected map[string]any
+	}{
+		{
+			MutationObserverInitT{ChildList: true, Subtree: true},
+			map[string]any{"childList": true, "subtree": true},
+		},
+		{
+			MutationObserverInitT{AttributeFilter: []string{"class"}, AttributeOldValue: true},
+			map[string]any{"attributes": true, "attributeFilter": []any{"class"}, "attributeOldValue": true},
+		},
+		{
+			MutationObserverInitT{CharacterDataOldValue: true},
+			map[string]any{"characterData": true, "characterDataOldValue": true},
+		},
+	}
+	for _, test := range tests {
+		found, err := test.opts.toMap()
+		if err != nil {
+			t.Errorf("%+v: unexpected error: %v\n", test.opts, err)
+		}
+		if !reflect.DeepEqual(test.expected, found) {
+			t.Errorf("expected: %+v but found: %+v\n", test.expected, found)
+		}
+	}
+
+	if _, err := (MutationObserverInitT{Subtree: true}).toMap(); err != errNoMutationTypes {
+		t.Errorf("expected: %v but found: %v\n", errNoMutationTypes, err)
+	}
+}
+
+func TestMutationObserverChanClosed(t *testing.T) {
+	obs, ch := NewMutationObserverChan()
+	if err := obs.Observe(Doc.CreateElement("div"), MutationObserverInitT{ChildList: true}); err != nil {
+		t.Fatalf("unexpected error: %v\n", err)
+	}
+	obs.Disconnect()
+	obs.Disconnect()
+
+	if _, ok := <-ch; ok {
+		t.Errorf("expected the channel to be closed\n")
+	}
+}
+
+func TestMutationObserverChanNotRead(t *testing.T) {
+	obs, ch := NewMutationObserverChan()
+	// more batches than the channel holds, with nobody reading
+	for range mutationChanBuffer + 4 {
+		obs.(*mutationObserverS).queue.push(nil)
+	}
+	timeout := time.After(time.Second)
+	for len(ch) < mutationChanBuffer {
+		select {
+		case <-timeout:
+			t.Fatalf("expected: %d batches held but found: %d\n", mutationChanBuffer, len(ch))
+		default:
+			time.Sleep(time.Millisecond)
+		}
+	}
+
+	// the batches that did not fit are dropped and the channel is closed
+	obs.Disconnect()
+	for {
+		select {
+		case _, ok := <-ch:
+			if !ok {
+				return
+			}
+		case <-timeout:
+			t.Fatalf("expected the channel to be closed after Disconnect\n")
+		}
+	}
+}
+
+func TestObserveMutationsDisconnect(t *testing.T) {
+	target := NewElement(valueS{})
+	obs, err := ObserveMutations(target, MutationObserverInitT{ChildList: true}, func([]MutationRecordT) {})
+	if err != nil {
+		t.Fatalf("unexpected error: %v\n", err)
+	}
+
+	// a manual Disconnect leaves nothing to run when the target is removed
+	obs.Disconnect()
+	target.mutex.Lock()
+	left := len(target.cleanups)
+	target.mutex.Unlock()
+	if left != 0 {
+		t.Errorf("expected: %+v but found: %+v\n", 0, left)
+	}
+	target.Remove()
+}
@@ -0,0 +1,335 @@
+package dom
+
+// https://developer.mozilla.org/en-US/docs/Web/API/Node/nodeType
+const (
+	NodeType_Element               = 1
+	NodeType_Attribute             = 2
+	NodeType_Text                  = 3
+	NodeType_CDataSection          = 4
+	NodeType_ProcessingInstruction = 7
+	NodeType_Comment               = 8
+	NodeType_Document              = 9
+	NodeType_DocumentType          = 10
+	NodeType_DocumentFragment      = 11
+)
+
+// https://developer.mozilla.org/en-US/docs/Web/API/CharacterData
+// It is an ElementI so it fits in the tree, but the element-only methods,
+// such as SetAttribute or Focus, do nothing.
+type CharacterDataI interface {
+	ElementI
+
+	Data() string                               // https://developer.mozilla.org/en-US/docs/Web/API/CharacterData/data
+	SetData(string)                             // https://developer.mozilla.org/en-US/docs/Web/API/CharacterData/data
+	AppendData(data string)                     // https://developer.mozilla.org/en-US/docs/Web/API/CharacterData/appendData
+	DeleteData(offset, count int)               // https://developer.mozilla.org/en-US/docs/Web/API/CharacterData/deleteData
+	InsertData(offset int, data string)         // https://developer.mozilla.org/en-US/docs/Web/API/CharacterData/insertData
+	ReplaceData(offset, count int, data string) // https://developer.mozilla.org/en-US/docs/Web/API/CharacterData/replaceData
+	SubstringData(offset, count int) string     // https://developer.mozilla.org/en-US/docs/Web/API/CharacterData/substringData
+}
+
+// https://developer.mozilla.org/en-US/docs/Web/API/Text
+type TextNodeI interface {
+	CharacterDataI
+
+	SplitText(offset int) TextNodeI // https://developer.mozilla.org/en-US/docs/Web/API/Text/splitText
+	WholeText() string              // https://developer.mozilla.org/en-US/docs/Web/API/Text/wholeText
+}
+
+// https://developer.mozilla.org/en-US/docs/Web/API/Comment
+type CommentI interface {
+	CharacterDataI
+}
+
+// https://developer.mozilla.org/en-US/docs/Web/API/DocumentFragment
+// Appending or inserting a fragment into an element moves the fragment's
+// children into that element and leaves the fragment empty.
+type DocumentFragmentI interface {
+	ElementI
+
+	Append(children ...ElementI) DocumentFragmentI
+}
+
+// newNode wraps a node without assigning it an id. Used for nodes that are
+// not elements, where an id has no meaning.
+func newNode(val ValueI) *elementS {
+	ret := &elementS{
+		ValueI:         val,
+		eventListeners: map[string]EventListenerI{},
+		children:       []ElementI{},
+	}
+	return ret
+}
+
+// NewNode wraps val with the wrapper matching its node type.
+// Element nodes are wrapped with NewElement. Other nodes, such as the
+// document, are not given an id. Returns nil for null or undefined.
+func NewNode(val ValueI) ElementI {
+	if val.IsNull() || val.IsUndefined() {
+		return nil
+	}
+
+	switch val.Get("nodeType").Int() {
+	case NodeType_Element:
+		return NewElement(val)
+	case NodeType_Text:
+		return NewTextNode(val)
+	case NodeType_Comment:
+		return NewComment(val)
+	case NodeType_DocumentFragment:
+		return NewDocumentFragment(val)
+	default:
+		return newNode(val)
+	}
+}
+
+// observedNode wraps a node reported by the browser, such as by an observer,
+// without changing it. Unlike NewNode no id is assigned, as the node may
+// belong to code outside of Go.
+func observedNode(val ValueI) ElementI {
+	if val.IsNull() || val.IsUndefined() {
+		return nil
+	}
+
+	switch val.Get("nodeType").Int() {
+	case NodeType_Text:
+		return NewTextNode(val)
+	case NodeType_Comment:
+		return NewComment(val)
+	default:
+		return newNode(val)
+	}
+}
+
+// observedNodes wraps the nodes of a NodeList or an array with observedNode.
+func observedNodes(list ValueI) []ElementI {
+	var out []ElementI
+	for _, val := range nodeListToObjects(list) {
+		out = append(out, observedNode(val))
+	}
+	return out
+}
+
+////
+////
+////
+
+// nodeS is the base of text and comment nodes. They keep the tree,
+// listener and cleanup bookkeeping of elementS, so they can be appended,
+// inserted and removed like elements, but the element-only methods of
+// ElementI do nothing: getters return zero values or nil, and nothing is
+// called in JavaScript, where these nodes lack the methods.
+type nodeS struct {
+	*elementS
+}
+
+var _ ElementI = nodeS{}
+
+// element
+func (nodeS) Attributes() map[string]string            { return nil }
+func (nodeS) Class() TokenListI                        { return nil }
+func (nodeS) Closest(string) ElementI                  { return nil }
+func (nodeS) ID() string                               { return "" }
+func (nodeS) SetID(string)                             {}
+func (nodeS) TagName() string                          { return "" }
+func (nodeS) GetAttribute(string) string               { return "" }
+func (nodeS) GetBoundingClientRect() RectI             { return nil }
+func (nodeS) GetClientRects() []RectI                  { return nil }
+func (nodeS) GetElementsByClassName(string) []ElementI { return nil }
+func (nodeS) GetElementsByTagName(string) []ElementI   { return nil }
+func (nodeS) HasAttribute(string) bool                 { return false }
+func (nodeS) Matches(string) bool                      { return false }
+func (nodeS) QuerySelector(string) ElementI            { return nil }
+func (nodeS) QuerySelectorAll(string) []ElementI       { return nil }
+func (nodeS) RemoveAttribute(string)                   {}
+func (nodeS) SetAttribute(string, string)              {}
+func (nodeS) InnerHTML() string                        { return "" }
+func (nodeS) SetInnerHTML(string)                      {}
+func (nodeS) OuterHTML() string                        { return "" }
+func (nodeS) SetOuterHTML(string)                      {}
+func (nodeS) AttachShadow(ShadowRootMode) ShadowRootI  { return nil }
+func (nodeS) ShadowRoot() ShadowRootI                  { return nil }
+
+// HTML element
+func (nodeS) ContentEditable() string   { return "" }
+func (nodeS) SetContentEditable(string) {}
+func (nodeS) IsContentEditable() bool   { return false }
+func (nodeS) Dataset() DatasetI         { return nil }
+func (nodeS) Draggable() bool           { return false }
+func (nodeS) SetDraggable(bool)         {}
+func (nodeS) OffsetHeight() float64     { return 0 }
+func (nodeS) OffsetLeft() float64       { return 0 }
+func (nodeS) OffsetParent() ElementI    { return nil }
+func (nodeS) OffsetTop() float64        { return 0 }
+func (nodeS) OffsetWidth() float64      { return 0 }
+func (nodeS) Style() CSSStyleI          { return nil }
+func (nodeS) Title() string             { return "" }
+func (nodeS) SetTitle(string)           {}
+func (nodeS) Blur()                     {}
+func (nodeS) Click()                    {}
+func (nodeS) Focus()                    {}
+func (nodeS) TabIndex() int             { return 0 }
+func (nodeS) SetTabIndex(int)           {}
+
+// accessibility
+func (nodeS) Role() AriaRole                 { return AriaRole_Unset }
+func (nodeS) SetRole(AriaRole)               {}
+func (nodeS) AriaLabel() string              { return "" }
+func (nodeS) SetAriaLabel(string)            {}
+func (nodeS) AriaLabelledBy() []string       { return nil }
+func (nodeS) SetAriaLabelledBy(...ElementI)  {}
+func (nodeS) AriaDescribedBy() []string      { return nil }
+func (nodeS) SetAriaDescribedBy(...ElementI) {}
+func (nodeS) AriaExpanded() bool             { return false }
+func (nodeS) SetAriaExpanded(bool)           {}
+func (nodeS) AriaSelected() bool             { return false }
+func (nodeS) SetAriaSelected(bool)           {}
+func (nodeS) AriaLive() AriaLive             { return "" }
+func (nodeS) SetAriaLive(AriaLive)           {}
+
+// animation
+func (nodeS) Animate([]KeyframeT, AnimationOptionsT) AnimationI { return nil }
+func (nodeS) GetAnimations() []AnimationI                       { return nil }
+
+// scrolling
+func (nodeS) ClientHeight() float64                     { return 0 }
+func (nodeS) ClientWidth() float64                      { return 0 }
+func (nodeS) ClientTop() float64                        { return 0 }
+func (nodeS) ClientLeft() float64                       { return 0 }
+func (nodeS) ScrollHeight() float64                     { return 0 }
+func (nodeS) ScrollWidth() float64                      { return 0 }
+func (nodeS) ScrollTop() float64                        { return 0 }
+func (nodeS) SetScrollTop(float64)                      {}
+func (nodeS) ScrollLeft() float64                       { return 0 }
+func (nodeS) SetScrollLeft(float64)                     {}
+func (nodeS) ScrollTo(float64, float64, ScrollBehavior) {}
+func (nodeS) ScrollBy(float64, float64, ScrollBehavior) {}
+func (nodeS) ScrollIntoView(ScrollIntoViewOptionsT)     {}
+func (nodeS) ScrollToBottom(ScrollBehavior)             {}
+func (nodeS) IsScrolledToBottom(float64) bool           { return false }
+
+// OnScroll adds a scroll listener, which never fires as only elements scroll.
+func (s nodeS) OnScroll(listener func(ScrollInfoT)) EventListenerI {
+	return s.AddEventListener("scroll", false, func(EventI) { listener(ScrollInfoT{}) })
+}
+
+////
+////
+////
+
+type characterDataS struct {
+	nodeS
+}
+
+var _ CharacterDataI = characterDataS{}
+
+func (s characterDataS) Data() string {
+	return s.Get("data").String()
+}
+
+func (s characterDataS) SetData(data string) {
+	s.Set("data", data)
+}
+
+func (s characterDataS) AppendData(data string) {
+	s.Call("appendData", data)
+}
+
+func (s characterDataS) DeleteData(offset, count int) {
+	s.Call("deleteData", offset, count)
+}
+
+func (s characterDataS) InsertData(offset int, data string) {
+	s.Call("insertData", offset, data)
+}
+
+func (s characterDataS) ReplaceData(offset, count int, data string) {
+	s.Call("replaceData", offset, count, data)
+}
+
+func (s characterDataS) SubstringData(offset, count int) string {
+	return s.Call("substringData", offset, count).String()
+}
+
+////
+////
+////
+
+type textNodeS struct {
+	characterDataS
+}
+
+var _ TextNodeI = &textNodeS{}
+
+func NewTextNode(val ValueI) *textNodeS {
+	ret := &textNodeS{
+		characterDataS: characterDataS{nodeS{newNode(val)}},
+	}
+	return ret
+}
+
+func (s *textNodeS) SplitText(offset int) TextNodeI {
+	return NewTextNode(s.Call("splitText", offset))
+}
+
+func (s *textNodeS) WholeText() string {
+	return s.Get("wholeText").String()
+}
+
+////
+////
+////
+
+type commentS struct {
+	characterDataS
+}
+
+var _ CommentI = &commentS{}
+
+func NewComment(val ValueI) *commentS {
+	ret := &commentS{
+		characterDataS: characterDataS{nodeS{newNode(val)}},
+	}
+	return ret
+}
+
+////
+////
+////
+
+type documentFragmentS struct {
+	*elementS
+}
+
+var _ DocumentFragmentI = &documentFragmentS{}
+
+func NewDocumentFragment(val ValueI) *documentFragmentS {
+	ret := &documentFragmentS{
+		elementS: newNode(val),
+	}
+	return ret
+}
+
+func (s *documentFragmentS) Append(children ...ElementI) DocumentFragmentI {
+	for _, child := range children {
+		s.AppendChild(child)
+	}
+	return s
+}
+
+// Remove releases the fragment's children. A fragment is never attached to
+// the document, so there is nothing to detach.
+func (s *documentFragmentS) Remove() {
+	s.RemoveChildren()
+	s.RemoveAllEventListeners()
+}
+
+// insertedChildren returns the nodes that end up in the tree when newChild
+// is inserted.
+func insertedChildren(newChild ElementI) []ElementI {
+	// the browser empties a fragment when it is inserted, so its bookkeeping goes too
+	if frag, ok := newChild.(*documentFragmentS); ok {
+		return frag.takeChildren()
+	}
+	return []ElementI{newChild}
+}
@@ -0,0 +1,333 @@
+package dom
+
+import (
+	"slices"
+	"sync"
+	"time"
+)
+
+/*
+Releasing what wrappers hold. Listeners, OnRemove cleanups, timers and
+observers all belong to an ElementI wrapper, and a node can have any number
+of wrappers: the one from NewElement, the ones from QuerySelector, event
+targets and so on. Every wrapper that holds something is registered under a
+key stored on its node, so Remove, RemoveChildren, SetInnerHTML,
+SetTextContent and SetOuterHTML can find all of them in the subtree they
+drop, not just the children appended through Go:
+
+	row := table.QuerySelector("tr")
+	row.AddEventListener("click", false, onClick)
+	row.SetTimeout(time.Second, highlight)
+
+	table.Remove() // removes the listener and cancels the timer
+
+RemoveChild and ReplaceChild release the child they take out in the same
+way. Nodes that code outside of Go takes out of the document are released
+too, once they are still out of it after the change, so a node that is
+only moved keeps what its wrappers hold.
+*/
+
+// nodeKeyProperty holds a node's key in nodeRegistry.
+const nodeKeyProperty = "__goDomNodeKey"
+
+// nodeRegistryS holds the wrappers that have something to release, by the
+// key of their node.
+type nodeRegistryS struct {
+	mutex    sync.Mutex
+	nextKey  int
+	wrappers map[int][]*elementS
+
+	watchOnce sync.Once
+}
+
+var nodeRegistry = nodeRegistryS{wrappers: map[int][]*elementS{}}
+
+// add registers e under the key of its node, giving the node a key if it
+// has none yet. Registering a wrapper twice does nothing.
+func (r *nodeRegistryS) add(e *elementS) {
+	r.watchDetached()
+
+	r.mutex.Lock()
+	registered := e.registryKey != 0
+	r.mutex.Unlock()
+	if registered {
+		return
+	}
+
+	// the node is read and written outside the lock, like every JS call
+	key := r.nodeKey(e.ValueI)
+	if key == 0 {
+		r.mutex.Lock()
+		r.nextKey++
+		key = r.nextKey
+		r.mutex.Unlock()
+		e.Set(nodeKeyProperty, key)
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if e.registryKey != 0 {
+		return
+	}
+	e.registryKey = key
+	r.wrappers[key] = append(r.wrappers[key], e)
+}
+
+// remove forgets e.
+func (r *nodeRegistryS) remove(e *elementS) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if e.registryKey == 0 {
+		return
+	}
+
+	key := e.registryKey
+	e.registryKey = 0
+	r.wrappers[key] = slices.DeleteFunc(r.wrappers[key], func(w *elementS) bool { return w == e })
+	if len(r.wrappers[key]) == 0 {
+		delete(r.wrappers, key)
+	}
+}
+
+// wrappersOf returns the registered wrappers of val's node.
+func (r *nodeRegistryS) wrappersOf(val ValueI) []*elementS {
+	key := r.nodeKey(val)
+	if key == 0 {
+		return nil
+	}
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return slices.Clone(r.wrappers[key])
+}
+
+// nodeKey returns the key stored on a node, or 0 if it has none.
+func (r *nodeRegistryS) nodeKey(val ValueI) int {
+	key := val.Get(nodeKeyProperty)
+	if key.Type() != TypeNumber {
+		return 0
+	}
+	return key.Int()
+}
+
+// len returns how many wrappers are registered.
+func (r *nodeRegistryS) len() int {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	n := 0
+	for _, wrappers := range r.wrappers {
+		n += len(wrappers)
+	}
+	return n
+}
+
+// releaseInside releases every registered wrapper of the elements below
+// root, and of root itself when includeRoot is set.
+func (r *nodeRegistryS) releaseInside(root ValueI, includeRoot bool) {
+	r.mutex.Lock()
+	empty := len(r.wrappers) == 0
+	r.mutex.Unlock()
+	if empty {
+		return
+	}
+
+	var nodes []ValueI
+	if includeRoot {
+		nodes = append(nodes, root)
+	}
+	// text and comment nodes have no descendants to search
+	if root.Get("firstElementChild").Truthy() {
+		nodes = append(nodes, nodeListToObjects(root.Call("querySelectorAll", "*"))...)
+	}
+	var keys []int
+	for _, node := range nodes {
+		if key := r.nodeKey(node); key != 0 {
+			keys = append(keys, key)
+		}
+	}
+
+	r.mutex.Lock()
+	var found []*elementS
+	for _, key := range keys {
+		for _, w := range r.wrappers[key] {
+			w.registryKey = 0
+			found = append(found, w)
+		}
+		delete(r.wrappers, key)
+	}
+	r.mutex.Unlock()
+
+	// outside the lock, as cleanups may register or release other wrappers
+	for _, w := range found {
+		w.releaseResources()
+	}
+}
+
+// watchDetached starts, once, watching the document for nodes taken out of
+// it by code outside of Go.
+func (r *nodeRegistryS) watchDetached() {
+	if Doc == nil {
+		// while Doc itself is created
+		return
+	}
+	r.watchOnce.Do(func() {
+		fn := NewFuncForJavascript(func(this ValueI, args []ValueI) any {
+			r.releaseDetached(args[0])
+			return nil
+		})
+		observer := Window.Underlying().Get("MutationObserver").New(fn)
+		observer.Call("observe", Doc.Underlying(), map[string]any{"childList": true, "subtree": true})
+	})
+}
+
+// releaseDetached releases the wrappers inside the nodes removed by the
+// mutation records that are not in the document any more. Nodes released
+// by Go have no wrappers left to find.
+func (r *nodeRegistryS) releaseDetached(records ValueI) {
+	for _, record := range arrayToObjects(records) {
+		for _, node := range nodeListToObjects(record.Get("removedNodes")) {
+			if !node.Get("isConnected").Bool() {
+				r.releaseInside(node, true)
+			}
+		}
+	}
+}
+
+////
+////
+////
+
+type cleanupT struct {
+	id int
+	fn func()
+}
+
+// OnRemove registers fn to run once when the element is removed with
+// Remove, or when an ancestor is removed or its contents replaced. Call
+// cancel to unregister fn without running it.
+func (e *elementS) OnRemove(fn func()) (cancel func()) {
+	e.mutex.Lock()
+	e.nextCleanupID++
+	id := e.nextCleanupID
+	e.cleanups = append(e.cleanups, cleanupT{id: id, fn: fn})
+	e.mutex.Unlock()
+
+	nodeRegistry.add(e)
+	return func() {
+		e.mutex.Lock()
+		defer e.mutex.Unlock()
+		e.cleanups = slices.DeleteFunc(e.cleanups, func(c cleanupT) bool { return c.id == id })
+	}
+}
+
+// runCleanups runs the OnRemove functions in the order they were registered.
+func (e *elementS) runCleanups() {
+	e.mutex.Lock()
+	cleanups := e.cleanups
+	e.cleanups = nil
+	e.mutex.Unlock()
+
+	for _, c := range cleanups {
+		c.fn()
+	}
+}
+
+// SetTimeout calls fn in a new goroutine after d, unless cancel is called or
+// the element is removed first.
+func (e *elementS) SetTimeout(d time.Duration, fn func()) (cancel func()) {
+	stop := make(chan struct{})
+	var once sync.Once
+	stopOnce := func() { once.Do(func() { close(stop) }) }
+	removed := e.OnRemove(stopOnce)
+
+	go func() {
+		timer := time.NewTimer(d)
+		defer timer.Stop()
+		select {
+		case <-timer.C:
+			removed()
+			fn()
+		case <-stop:
+		}
+	}()
+
+	return func() {
+		removed()
+		stopOnce()
+	}
+}
+
+// SetInterval calls fn every d, each time in the same goroutine, until
+// cancel is called or the element is removed.
+func (e *elementS) SetInterval(d time.Duration, fn func()) (cancel func()) {
+	stop := make(chan struct{})
+	var once sync.Once
+	stopOnce := func() { once.Do(func() { close(stop) }) }
+	removed := e.OnRemove(stopOnce)
+
+	go func() {
+		ticker := time.NewTicker(d)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				fn()
+			case <-stop:
+				return
+			}
+		}
+	}()
+
+	return func() {
+		removed()
+		stopOnce()
+	}
+}
+
+// releaseResources removes the wrapper's listeners, runs its OnRemove
+// functions and releases its shadow root. The node itself is left alone.
+func (e *elementS) releaseResources() {
+	nodeRegistry.remove(e)
+	e.RemoveAllEventListeners()
+	e.runCleanups()
+
+	e.mutex.Lock()
+	shadowRoot := e.shadowRoot
+	e.shadowRoot = nil
+	e.mutex.Unlock()
+	if shadowRoot != nil {
+		shadowRoot.Remove()
+	}
+}
+
+// treeReleaserI is implemented by the wrappers of this package, which can
+// release a subtree without detaching every node of it one by one.
+type treeReleaserI interface {
+	releaseTree()
+}
+
+// releaseTree releases the wrapper and the children tracked below it.
+func (e *elementS) releaseTree() {
+	for _, child := range e.takeChildren() {
+		releaseChild(child)
+	}
+	e.releaseResources()
+}
+
+// releaseDetachedChild releases a child taken out of its parent, leaving
+// the node itself to the caller.
+func releaseDetachedChild(child ElementI) {
+	nodeRegistry.releaseInside(child.Underlying(), true)
+	if r, ok := child.(treeReleaserI); ok {
+		r.releaseTree()
+	}
+}
+
+// releaseChild releases a tracked child. Children implemented outside of
+// the package are released through their own Remove.
+func releaseChild(child ElementI) {
+	if r, ok := child.(treeReleaserI); ok {
+		r.releaseTree()
+		return
+	}
+	child.Remove()
+}
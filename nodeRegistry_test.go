@@ -0,0 +1,147 @@
+package dom
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestRemoveRunsCleanupsOfTheSubtree(t *testing.T) {
+	registered := nodeRegistry.len()
+
+	parent := NewElement(valueS{})
+	child := parent.AppendChild(NewElement(valueS{}))
+	grandchild := child.AppendChild(NewElement(valueS{}))
+
+	var order []string
+	parent.OnRemove(func() { order = append(order, "parent") })
+	grandchild.OnRemove(func() { order = append(order, "grandchild") })
+	cancel := child.OnRemove(func() { order = append(order, "canceled") })
+	cancel()
+	child.AddEventListener("click", false, func(EventI) {})
+
+	if nodeRegistry.len() != registered+3 {
+		t.Errorf("expected: %+v but found: %+v\n", registered+3, nodeRegistry.len())
+	}
+
+	parent.Remove()
+	expected := []string{"grandchild", "parent"}
+	if len(order) != len(expected) || order[0] != expected[0] || order[1] != expected[1] {
+		t.Errorf("expected: %+v but found: %+v\n", expected, order)
+	}
+	if nodeRegistry.len() != registered {
+		t.Errorf("expected: %+v but found: %+v\n", registered, nodeRegistry.len())
+	}
+
+	// a second Remove has nothing left to run
+	parent.Remove()
+	if len(order) != len(expected) {
+		t.Errorf("expected: %+v but found: %+v\n", expected, order)
+	}
+}
+
+func TestReplacingContentsRunsCleanups(t *testing.T) {
+	parent := NewElement(valueS{})
+	child := parent.AppendChild(NewElement(valueS{}))
+
+	count := 0
+	child.OnRemove(func() { count++ })
+	parent.SetInnerHTML("<p>replaced</p>")
+	if count != 1 || len(parent.ChildNodes()) != 0 {
+		t.Errorf("expected: 1 cleanup and no children but found: %d cleanups and %d children\n", count, len(parent.ChildNodes()))
+	}
+
+	child = parent.AppendChild(NewElement(valueS{}))
+	child.OnRemove(func() { count++ })
+	parent.SetTextContent("replaced")
+	if count != 2 {
+		t.Errorf("expected: %+v but found: %+v\n", 2, count)
+	}
+}
+
+func TestRemoveCancelsTimers(t *testing.T) {
+	e := NewElement(valueS{})
+	fired := make(chan string, 2)
+	e.SetTimeout(20*time.Millisecond, func() { fired <- "timeout" })
+	e.SetInterval(20*time.Millisecond, func() { fired <- "interval" })
+	e.Remove()
+
+	select {
+	case got := <-fired:
+		t.Errorf("expected: nothing but found: %+v\n", got)
+	case <-time.After(60 * time.Millisecond):
+	}
+}
+
+func TestSetTimeoutFires(t *testing.T) {
+	e := NewElement(valueS{})
+	fired := make(chan struct{})
+	e.SetTimeout(time.Millisecond, func() { close(fired) })
+
+	select {
+	case <-fired:
+	case <-time.After(time.Second):
+		t.Fatal("expected the timeout to fire")
+	}
+
+	e.mutex.Lock()
+	left := len(e.cleanups)
+	e.mutex.Unlock()
+	if left != 0 {
+		t.Errorf("expected: %+v but found: %+v\n", 0, left)
+	}
+	e.Remove()
+}
+
+func TestRemoveReleasesUntrackedWrappers(t *testing.T) {
+	registered := nodeRegistry.len()
+	rowVal := fakeNode("tr", nil, fakeNode("td", nil))
+	table := newNode(fakeNode("table", nil, rowVal))
+
+	// a wrapper Go only knows through the page
+	row := table.QuerySelector("tr")
+	row.AddEventListener("click", false, func(EventI) {})
+	removed := 0
+	row.OnRemove(func() { removed++ })
+
+	table.Remove()
+	if removed != 1 || len(rowVal.listeners) != 0 {
+		t.Errorf("expected: 1 cleanup and no listeners but found: %d and %+v\n", removed, rowVal.listeners)
+	}
+	if nodeRegistry.len() != registered {
+		t.Errorf("expected: %+v but found: %+v\n", registered, nodeRegistry.len())
+	}
+}
+
+func TestRemoveChildReleases(t *testing.T) {
+	registered := nodeRegistry.len()
+	itemVal := fakeNode("li", nil)
+	list := newNode(fakeNode("ul", nil, itemVal))
+	item := list.AppendChild(newNode(itemVal))
+	other := newNode(itemVal)
+
+	removed := 0
+	item.OnRemove(func() { removed++ })
+	other.OnRemove(func() { removed++ })
+	list.RemoveChild(item)
+	if removed != 2 || nodeRegistry.len() != registered {
+		t.Errorf("expected: 2 cleanups and %d wrappers but found: %d and %d\n", registered, removed, nodeRegistry.len())
+	}
+}
+
+func TestReleaseDetached(t *testing.T) {
+	moved := fakeNode("p", map[string]any{"isConnected": true})
+	dropped := fakeNode("div", map[string]any{"isConnected": false}, fakeNode("span", nil))
+
+	var released []string
+	newNode(moved).OnRemove(func() { released = append(released, "moved") })
+	newNode(dropped).OnRemove(func() { released = append(released, "dropped") })
+	newNode(dropped.children[0]).OnRemove(func() { released = append(released, "inside") })
+
+	record := fakeNode("", map[string]any{"removedNodes": []*fakeNodeS{moved, dropped}})
+	nodeRegistry.releaseDetached(fakeListS{nodes: []*fakeNodeS{record}})
+	if expected := []string{"dropped", "inside"}; !reflect.DeepEqual(expected, released) {
+		t.Errorf("expected: %+v but found: %+v\n", expected, released)
+	}
+	newNode(moved).Remove()
+}
@@ -0,0 +1,107 @@
+package dom
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNewNode(t *testing.T) {
+	tests := []struct {
+		nodeType int
+		expected reflect.Type
+		id       bool
+	}{
+		{NodeType_Element, reflect.TypeOf(&elementS{}), true},
+		{NodeType_Text, reflect.TypeOf(&textNodeS{}), false},
+		{NodeType_Comment, reflect.TypeOf(&commentS{}), false},
+		{NodeType_DocumentFragment, reflect.TypeOf(&documentFragmentS{}), false},
+		{NodeType_Document, reflect.TypeOf(&elementS{}), false},
+	}
+	for _, test := range tests {
+		val := fakeNode("", map[string]any{"nodeType": test.nodeType})
+		found := reflect.TypeOf(NewNode(val))
+		_, id := val.props["id"]
+		if found != test.expected || id != test.id {
+			t.Errorf("node type %d: expected: %v with id %v but found: %v with id %v\n", test.nodeType, test.expected, test.id, found, id)
+		}
+	}
+
+	if found := NewNode(fakeScalarS{}); found != nil {
+		t.Errorf("expected: nil but found: %+v\n", found)
+	}
+}
+
+func TestTextNodeElementMethods(t *testing.T) {
+	val := fakeNode("", map[string]any{"nodeType": NodeType_Text, "data": "hello"})
+	text := NewTextNode(val)
+
+	text.SetAttribute("title", "x")
+	text.SetID("x")
+	text.SetRole(AriaRole_Button)
+	text.Focus()
+	text.ScrollTo(0, 10, ScrollBehavior_Auto)
+	if text.ID() != "" || text.TagName() != "" || text.GetAttribute("title") != "" || text.Matches("*") {
+		t.Errorf("expected: zero values but found: %q, %q, %q, %v\n", text.ID(), text.TagName(), text.GetAttribute("title"), text.Matches("*"))
+	}
+	if len(val.calls) != 0 {
+		t.Errorf("expected: no calls but found: %+v\n", val.calls)
+	}
+	if text.Data() != "hello" {
+		t.Errorf("expected: %+v but found: %+v\n", "hello", text.Data())
+	}
+}
+
+// sameNodes reports whether found holds exactly the wrappers in expected.
+func sameNodes(found []ElementI, expected ...ElementI) bool {
+	if len(found) != len(expected) {
+		return false
+	}
+	for i := range found {
+		if found[i] != expected[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestChildBookkeeping(t *testing.T) {
+	parent := NewElement(valueS{})
+	a, b := NewElement(valueS{}), NewElement(valueS{})
+	text := Doc.CreateTextNode("x")
+
+	parent.AppendChildren(a, text, b)
+	if !sameNodes(parent.ChildNodes(), a, text, b) || parent.FirstChild() != a || parent.LastChild() != b {
+		t.Errorf("expected: a, text, b but found: %+v\n", parent.ChildNodes())
+	}
+
+	// inserting a child again moves it
+	parent.AppendChild(a)
+	if !sameNodes(parent.ChildNodes(), text, b, a) {
+		t.Errorf("expected: text, b, a but found: %+v\n", parent.ChildNodes())
+	}
+	parent.InsertBefore(a, text)
+	if !sameNodes(parent.ChildNodes(), a, text, b) {
+		t.Errorf("expected: a, text, b but found: %+v\n", parent.ChildNodes())
+	}
+
+	parent.RemoveChild(text)
+	parent.ReplaceChild(text, b)
+	if !sameNodes(parent.ChildNodes(), a, text) {
+		t.Errorf("expected: a, text but found: %+v\n", parent.ChildNodes())
+	}
+
+	// a fragment hands its children over and is left empty
+	frag := Doc.CreateDocumentFragment()
+	c := NewElement(valueS{})
+	frag.Append(c)
+	parent.InsertBefore(frag, text)
+	if !sameNodes(parent.ChildNodes(), a, c, text) || len(frag.ChildNodes()) != 0 {
+		t.Errorf("expected: a, c, text and an empty fragment but found: %+v and %+v\n", parent.ChildNodes(), frag.ChildNodes())
+	}
+
+	parent.RemoveChildren()
+	if len(parent.ChildNodes()) != 0 || parent.FirstChild() != nil {
+		t.Errorf("expected: no children but found: %+v\n", parent.ChildNodes())
+	}
+	parent.Remove()
+}
@@ -0,0 +1,131 @@
+package dom
+
+import (
+	"slices"
+	"sync"
+)
+
+// deliveryQueueS hands values pushed by JavaScript callbacks to a Go
+// function on a goroutine of its own, one at a time and in the order they
+// were pushed. The JavaScript callback returns straight away, as it does for
+// event listeners, but unlike a goroutine per call the order is kept.
+type deliveryQueueS[T any] struct {
+	// coalesce, if set, merges a pushed value into the last one still
+	// waiting, so a slow consumer gets the latest state instead of a backlog.
+	coalesce func(last, next T) T
+
+	mutex   sync.Mutex
+	cond    *sync.Cond
+	pending []T
+	closed  bool
+}
+
+// newDeliveryQueue starts the goroutine calling deliver. done, if not nil,
+// is called after close once everything pushed before it was delivered.
+func newDeliveryQueue[T any](deliver func(T), done func()) *deliveryQueueS[T] {
+	q := &deliveryQueueS[T]{}
+	q.cond = sync.NewCond(&q.mutex)
+
+	go func() {
+		for {
+			q.mutex.Lock()
+			for len(q.pending) == 0 && !q.closed {
+				q.cond.Wait()
+			}
+			if len(q.pending) == 0 {
+				q.mutex.Unlock()
+				if done != nil {
+					done()
+				}
+				return
+			}
+			v := q.pending[0]
+			q.pending = q.pending[1:]
+			q.mutex.Unlock()
+
+			deliver(v)
+		}
+	}()
+	return q
+}
+
+// push queues v. Values pushed after close are dropped.
+func (q *deliveryQueueS[T]) push(v T) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+	if q.closed {
+		return
+	}
+	if q.coalesce != nil && len(q.pending) > 0 {
+		q.pending[len(q.pending)-1] = q.coalesce(q.pending[len(q.pending)-1], v)
+		return
+	}
+	q.pending = append(q.pending, v)
+	q.cond.Signal()
+}
+
+func (q *deliveryQueueS[T]) close() {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+	q.closed = true
+	q.cond.Signal()
+}
+
+// observedTargetsS remembers the elements passed to an observer, so entries
+// report the ElementI that was observed instead of a new wrapper. Targets
+// stop being observed when they are removed.
+type observedTargetsS struct {
+	mutex   sync.Mutex
+	targets []ElementI
+	cancels []func() // cancels the OnRemove of the target at the same index
+}
+
+// add remembers target and has unobserve called when it is removed.
+func (o *observedTargetsS) add(target ElementI, unobserve func()) {
+	o.mutex.Lock()
+	known := slices.Contains(o.targets, target)
+	o.mutex.Unlock()
+	if known {
+		return
+	}
+
+	cancel := target.OnRemove(unobserve)
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+	o.targets = append(o.targets, target)
+	o.cancels = append(o.cancels, cancel)
+}
+
+// remove forgets target and returns how many targets are left.
+func (o *observedTargetsS) remove(target ElementI) int {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+	if i := slices.Index(o.targets, target); i >= 0 {
+		o.cancels[i]()
+		o.targets = slices.Delete(o.targets, i, i+1)
+		o.cancels = slices.Delete(o.cancels, i, i+1)
+	}
+	return len(o.targets)
+}
+
+func (o *observedTargetsS) clear() {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+	for _, cancel := range o.cancels {
+		cancel()
+	}
+	o.targets = nil
+	o.cancels = nil
+}
+
+// find returns the observed ElementI of the node val.
+func (o *observedTargetsS) find(val ValueI) ElementI {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+	for _, t := range o.targets {
+		if t.Underlying().Equal(val) {
+			return t
+		}
+	}
+	return observedNode(val)
+}
@@ -0,0 +1,27 @@
+package dom
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDeliveryQueueOrder(t *testing.T) {
+	var found []int
+	done := make(chan struct{})
+	q := newDeliveryQueue(func(v int) { found = append(found, v) }, func() { close(done) })
+
+	for i := range 100 {
+		q.push(i)
+	}
+	q.close()
+	q.push(100)
+	<-done
+
+	expected := make([]int, 100)
+	for i := range expected {
+		expected[i] = i
+	}
+	if !reflect.DeepEqual(expected, found) {
+		t.Errorf("expected: %+v but found: %+v\n", expected, found)
+	}
+}
@@ -0,0 +1,53 @@
+package dom
+
+import (
+	"errors"
+	"sync"
+)
+
+// promiseResultT is what a JavaScript promise settled with.
+type promiseResultT struct {
+	value ValueI
+	err   error // set when the promise was rejected
+}
+
+// awaitPromise returns a channel that receives once the promise settles.
+// The callbacks are added straight away and released once it settles.
+// Receiving blocks, so it must not happen in a callback the browser is
+// waiting on.
+func awaitPromise(promise ValueI) <-chan promiseResultT {
+	ch := make(chan promiseResultT, 1)
+
+	var once sync.Once
+	var resolved, rejected funcS
+	settle := func(result promiseResultT) {
+		once.Do(func() {
+			resolved.Release()
+			rejected.Release()
+			ch <- result
+		})
+	}
+
+	resolved = NewFuncForJavascript(func(this ValueI, args []ValueI) any {
+		settle(promiseResultT{value: args[0]})
+		return nil
+	})
+	rejected = NewFuncForJavascript(func(this ValueI, args []ValueI) any {
+		settle(promiseResultT{err: jsError(args[0])})
+		return nil
+	})
+	promise.Call("then", resolved, rejected)
+	return ch
+}
+
+// jsError converts a JavaScript exception, usually a DOMException, to an error.
+func jsError(val ValueI) error {
+	if val.IsNull() || val.IsUndefined() {
+		return errors.New("promise rejected")
+	}
+	name, message := val.Get("name").String(), val.Get("message").String()
+	if name == "" && message == "" {
+		return errors.New(val.String())
+	}
+	return errors.New(name + ": " + message)
+}
@@ -17,6 +17,8 @@ type RectI interface {
 	SetRight(v float64)
 	SetBottom(v float64)
 	SetLeft(v float64)
+
+	Rect() RectT // a copy that no longer refers to the DOMRect
 }
 
 func NewRect(val ValueI) rectS {
@@ -49,3 +51,7 @@ func (s rectS) SetTop(v float64)    { s.Set("top", v) }
 func (s rectS) SetRight(v float64)  { s.Set("right", v) }
 func (s rectS) SetBottom(v float64) { s.Set("bottom", v) }
 func (s rectS) SetLeft(v float64)   { s.Set("left", v) }
+
+func (s rectS) Rect() RectT {
+	return RectT{X: s.X(), Y: s.Y(), Width: s.Width(), Height: s.Height()}
+}
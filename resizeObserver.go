@@ -0,0 +1,221 @@
+package dom
+
+import (
+	"errors"
+	"math"
+	"sync"
+)
+
+/*
+Reports changes to the size of elements, instead of polling OffsetWidth:
+
+	obs := ObserveResize(panel, func(entries []ResizeEntryT) {
+		size := entries[0].ContentBoxSize
+		...
+	})
+	defer obs.Disconnect()
+
+When the callback falls behind, sizes waiting to be delivered are merged so
+it only sees the latest size of each element.
+*/
+
+/*
+https://developer.mozilla.org/en-US/docs/Web/API/ResizeObserver/observe#box
+*/
+type ResizeObserverBox string
+
+const (
+	ResizeObserverBox_ContentBox            ResizeObserverBox = "content-box"
+	ResizeObserverBox_BorderBox             ResizeObserverBox = "border-box"
+	ResizeObserverBox_DevicePixelContentBox ResizeObserverBox = "device-pixel-content-box"
+)
+
+// ResizeSizeT is the size of a box in the element's writing mode. For
+// horizontal text the inline size is the width and the block size the height.
+// https://developer.mozilla.org/en-US/docs/Web/API/ResizeObserverSize
+type ResizeSizeT struct {
+	InlineSize float64
+	BlockSize  float64
+}
+
+func newResizeSize(list ValueI) ResizeSizeT {
+	if list.IsNull() || list.IsUndefined() || list.Length() == 0 {
+		return ResizeSizeT{}
+	}
+	// only multi-column layouts have more than one fragment, which no
+	// browser reports yet
+	val := list.Index(0)
+	return ResizeSizeT{
+		InlineSize: val.Get("inlineSize").Float(),
+		BlockSize:  val.Get("blockSize").Float(),
+	}
+}
+
+// ResizeEntryT is the new size of one observed element.
+// https://developer.mozilla.org/en-US/docs/Web/API/ResizeObserverEntry
+type ResizeEntryT struct {
+	Target                    ElementI
+	ContentRect               RectT
+	ContentBoxSize            ResizeSizeT
+	BorderBoxSize             ResizeSizeT
+	DevicePixelContentBoxSize ResizeSizeT // zero where the browser does not support it
+}
+
+// mergeResizeEntries adds next to last, keeping only the latest entry of each target.
+func mergeResizeEntries(last, next []ResizeEntryT) []ResizeEntryT {
+	out := make([]ResizeEntryT, 0, len(last)+len(next))
+	for _, entry := range last {
+		replaced := false
+		for _, newer := range next {
+			if newer.Target == entry.Target {
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			out = append(out, entry)
+		}
+	}
+	return append(out, next...)
+}
+
+////
+////
+////
+
+// https://developer.mozilla.org/en-US/docs/Web/API/ResizeObserver
+type ResizeObserverI interface {
+	Underlying() ValueI
+
+	Observe(target ElementI, box ResizeObserverBox) // https://developer.mozilla.org/en-US/docs/Web/API/ResizeObserver/observe
+	Unobserve(target ElementI)                      // https://developer.mozilla.org/en-US/docs/Web/API/ResizeObserver/unobserve
+
+	// Disconnect stops observing every target and releases the JavaScript
+	// callback. The observer cannot be used again.
+	Disconnect() // https://developer.mozilla.org/en-US/docs/Web/API/ResizeObserver/disconnect
+}
+
+type resizeObserverS struct {
+	ValueI
+	fn    funcS
+	queue *deliveryQueueS[[]ResizeEntryT]
+	once  sync.Once
+
+	targets observedTargetsS
+}
+
+var _ ResizeObserverI = &resizeObserverS{}
+
+// NewResizeObserver creates an observer that calls callback with the
+// elements whose size changed. Entries are delivered on a goroutine of their
+// own, and merged while the callback is busy.
+func NewResizeObserver(callback func(entries []ResizeEntryT)) ResizeObserverI {
+	ret := &resizeObserverS{
+		queue: newDeliveryQueue(callback, nil),
+	}
+	ret.queue.coalesce = mergeResizeEntries
+	ret.fn = NewFuncForJavascript(func(this ValueI, args []ValueI) any {
+		var entries []ResizeEntryT
+		for _, val := range arrayToObjects(args[0]) {
+			entries = append(entries, ret.newEntry(val))
+		}
+		ret.queue.push(entries)
+		return nil
+	})
+	ret.ValueI = Window.Underlying().Get("ResizeObserver").New(ret.fn)
+	return ret
+}
+
+// ObserveResize creates an observer watching the content box of target.
+// The observer is disconnected when target is removed.
+func ObserveResize(target ElementI, callback func(entries []ResizeEntryT)) ResizeObserverI {
+	ret := NewResizeObserver(callback)
+	ret.Observe(target, ResizeObserverBox_ContentBox)
+	target.OnRemove(ret.Disconnect)
+	return ret
+}
+
+func (r *resizeObserverS) newEntry(val ValueI) ResizeEntryT {
+	rect := val.Get("contentRect")
+	return ResizeEntryT{
+		Target:                    r.targets.find(val.Get("target")),
+		ContentRect:               RectT{X: rect.Get("x").Float(), Y: rect.Get("y").Float(), Width: rect.Get("width").Float(), Height: rect.Get("height").Float()},
+		ContentBoxSize:            newResizeSize(val.Get("contentBoxSize")),
+		BorderBoxSize:             newResizeSize(val.Get("borderBoxSize")),
+		DevicePixelContentBoxSize: newResizeSize(val.Get("devicePixelContentBoxSize")),
+	}
+}
+
+func (r *resizeObserverS) Underlying() ValueI {
+	return r.ValueI
+}
+
+func (r *resizeObserverS) Observe(target ElementI, box ResizeObserverBox) {
+	r.targets.add(target, func() { r.Unobserve(target) })
+	r.Call("observe", target.Underlying(), map[string]any{"box": string(box)})
+}
+
+func (r *resizeObserverS) Unobserve(target ElementI) {
+	r.Call("unobserve", target.Underlying())
+	r.targets.remove(target)
+}
+
+func (r *resizeObserverS) Disconnect() {
+	r.once.Do(func() {
+		r.Call("disconnect")
+		r.fn.Release()
+		r.queue.close()
+		r.targets.clear()
+	})
+}
+
+////
+////
+////
+
+// ErrResizeObserverUnsupported is returned by AutoResize in browsers without
+// ResizeObserver.
+var ErrResizeObserverUnsupported = errors.New("resize observer: not supported by the browser")
+
+// AutoResize keeps the canvas's backing store the same size as the canvas
+// on screen, in device pixels, so drawings stay sharp when the layout or the
+// zoom changes. onResize is called after every change with the new size and
+// should redraw, as resizing clears the canvas. Disconnect the returned
+// observer to stop. It is disconnected when the canvas is removed.
+func (s *CanvasS) AutoResize(onResize func(width, height int)) (ResizeObserverI, error) {
+	window := Window.Underlying()
+	if ctor := window.Get("ResizeObserver"); ctor.IsNull() || ctor.IsUndefined() {
+		return nil, ErrResizeObserverUnsupported
+	}
+	// browsers without device pixel sizes reject the box, so ask first
+	box := ResizeObserverBox_ContentBox
+	entry := window.Get("ResizeObserverEntry").Get("prototype")
+	if window.Get("Reflect").Call("has", entry, "devicePixelContentBoxSize").Bool() {
+		box = ResizeObserverBox_DevicePixelContentBox
+	}
+
+	ret := NewResizeObserver(func(entries []ResizeEntryT) {
+		entry := entries[len(entries)-1]
+		size := entry.DevicePixelContentBoxSize
+		if size == (ResizeSizeT{}) {
+			ratio := Window.Underlying().Get("devicePixelRatio").Float()
+			size = ResizeSizeT{
+				InlineSize: entry.ContentBoxSize.InlineSize * ratio,
+				BlockSize:  entry.ContentBoxSize.BlockSize * ratio,
+			}
+		}
+
+		width, height := int(math.Round(size.InlineSize)), int(math.Round(size.BlockSize))
+		if width == s.Width() && height == s.Height() {
+			return
+		}
+		s.SetWidth(width)
+		s.SetHeight(height)
+		if onResize != nil {
+			onResize(width, height)
+		}
+	})
+	ret.Observe(s, box)
+	s.OnRemove(ret.Disconnect)
+	return ret, nil
+}
@@ -0,0 +1,55 @@
+package dom
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMergeResizeEntries(t *testing.T) {
+	a, b, c := Doc.CreateElement("div"), Doc.CreateElement("div"), Doc.CreateElement("div")
+
+	last := []ResizeEntryT{
+		{Target: a, ContentBoxSize: ResizeSizeT{InlineSize: 1}},
+		{Target: b, ContentBoxSize: ResizeSizeT{InlineSize: 1}},
+	}
+	next := []ResizeEntryT{
+		{Target: a, ContentBoxSize: ResizeSizeT{InlineSize: 2}},
+		{Target: c, ContentBoxSize: ResizeSizeT{InlineSize: 2}},
+	}
+
+	expected := []ResizeEntryT{
+		{Target: b, ContentBoxSize: ResizeSizeT{InlineSize: 1}},
+		{Target: a, ContentBoxSize: ResizeSizeT{InlineSize: 2}},
+		{Target: c, ContentBoxSize: ResizeSizeT{InlineSize: 2}},
+	}
+	if found := mergeResizeEntries(last, next); !reflect.DeepEqual(expected, found) {
+		t.Errorf("expected: %+v but found: %+v\n", expected, found)
+	}
+}
+
+func TestDeliveryQueueCoalesce(t *testing.T) {
+	started := make(chan struct{}, 1)
+	release := make(chan struct{})
+	var found []int
+	done := make(chan struct{})
+	q := newDeliveryQueue(func(v int) {
+		started <- struct{}{}
+		<-release
+		found = append(found, v)
+	}, func() { close(done) })
+	q.coalesce = func(last, next int) int { return next }
+
+	q.push(1)
+	<-started // 1 is being delivered, so the rest wait together
+	q.push(2)
+	q.push(3)
+	q.push(4)
+	close(release)
+	q.close()
+	<-done
+
+	expected := []int{1, 4}
+	if !reflect.DeepEqual(expected, found) {
+		t.Errorf("expected: %+v but found: %+v\n", expected, found)
+	}
+}
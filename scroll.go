@@ -0,0 +1,244 @@
+package dom
+
+import "sync"
+
+/*
+https://developer.mozilla.org/en-US/docs/Web/API/Element/scrollTo#behavior
+*/
+type ScrollBehavior string
+
+const (
+	ScrollBehavior_Auto    ScrollBehavior = "auto"
+	ScrollBehavior_Smooth  ScrollBehavior = "smooth"
+	ScrollBehavior_Instant ScrollBehavior = "instant"
+)
+
+/*
+https://developer.mozilla.org/en-US/docs/Web/API/Element/scrollIntoView#block
+*/
+type ScrollLogicalPosition string
+
+const (
+	ScrollLogicalPosition_Start   ScrollLogicalPosition = "start"
+	ScrollLogicalPosition_Center  ScrollLogicalPosition = "center"
+	ScrollLogicalPosition_End     ScrollLogicalPosition = "end"
+	ScrollLogicalPosition_Nearest ScrollLogicalPosition = "nearest"
+)
+
+// ScrollIntoViewOptionsT leaves the browser default in place for empty fields.
+type ScrollIntoViewOptionsT struct {
+	Behavior ScrollBehavior
+	Block    ScrollLogicalPosition // vertical alignment
+	Inline   ScrollLogicalPosition // horizontal alignment
+}
+
+func (o ScrollIntoViewOptionsT) toMap() map[string]any {
+	m := map[string]any{}
+	if o.Behavior != "" {
+		m["behavior"] = string(o.Behavior)
+	}
+	if o.Block != "" {
+		m["block"] = string(o.Block)
+	}
+	if o.Inline != "" {
+		m["inline"] = string(o.Inline)
+	}
+	return m
+}
+
+type ScrollDirection int
+
+const (
+	ScrollDirection_None ScrollDirection = iota
+	ScrollDirection_Up
+	ScrollDirection_Down
+	ScrollDirection_Left
+	ScrollDirection_Right
+)
+
+func (d ScrollDirection) String() string {
+	switch d {
+	case ScrollDirection_None:
+		return "none"
+	case ScrollDirection_Up:
+		return "up"
+	case ScrollDirection_Down:
+		return "down"
+	case ScrollDirection_Left:
+		return "left"
+	case ScrollDirection_Right:
+		return "right"
+	default:
+		panic("bad scroll direction")
+	}
+}
+
+// ScrollInfoT describes an element's scroll position when a scroll event fired.
+type ScrollInfoT struct {
+	Top       float64
+	Left      float64
+	DeltaTop  float64 // change since the previous event
+	DeltaLeft float64
+	Direction ScrollDirection
+	AtTop     bool
+	AtBottom  bool
+}
+
+// scrollDirection picks the direction of the larger movement.
+func scrollDirection(dTop, dLeft float64) ScrollDirection {
+	absTop, absLeft := dTop, dLeft
+	if absTop < 0 {
+		absTop = -absTop
+	}
+	if absLeft < 0 {
+		absLeft = -absLeft
+	}
+
+	switch {
+	case absTop == 0 && absLeft == 0:
+		return ScrollDirection_None
+	case absTop >= absLeft && dTop < 0:
+		return ScrollDirection_Up
+	case absTop >= absLeft:
+		return ScrollDirection_Down
+	case dLeft < 0:
+		return ScrollDirection_Left
+	default:
+		return ScrollDirection_Right
+	}
+}
+
+////
+////
+////
+
+func (e *elementS) ClientHeight() float64 { return e.Get("clientHeight").Float() }
+func (e *elementS) ClientWidth() float64  { return e.Get("clientWidth").Float() }
+func (e *elementS) ClientTop() float64    { return e.Get("clientTop").Float() }
+func (e *elementS) ClientLeft() float64   { return e.Get("clientLeft").Float() }
+func (e *elementS) ScrollHeight() float64 { return e.Get("scrollHeight").Float() }
+func (e *elementS) ScrollWidth() float64  { return e.Get("scrollWidth").Float() }
+func (e *elementS) ScrollTop() float64    { return e.Get("scrollTop").Float() }
+func (e *elementS) ScrollLeft() float64   { return e.Get("scrollLeft").Float() }
+
+func (e *elementS) SetScrollTop(v float64)  { e.Set("scrollTop", v) }
+func (e *elementS) SetScrollLeft(v float64) { e.Set("scrollLeft", v) }
+
+func (e *elementS) ScrollTo(x, y float64, behavior ScrollBehavior) {
+	e.Call("scrollTo", scrollToOptions(x, y, behavior))
+}
+
+func (e *elementS) ScrollBy(dx, dy float64, behavior ScrollBehavior) {
+	e.Call("scrollBy", scrollToOptions(dx, dy, behavior))
+}
+
+// scrollToOptions leaves behavior out when it is empty, as the browser
+// rejects "" instead of using its default.
+func scrollToOptions(left, top float64, behavior ScrollBehavior) map[string]any {
+	m := map[string]any{"left": left, "top": top}
+	if behavior != "" {
+		m["behavior"] = string(behavior)
+	}
+	return m
+}
+
+func (e *elementS) ScrollIntoView(opts ScrollIntoViewOptionsT) {
+	e.Call("scrollIntoView", opts.toMap())
+}
+
+func (e *elementS) ScrollToBottom(behavior ScrollBehavior) {
+	e.ScrollTo(e.ScrollLeft(), e.ScrollHeight(), behavior)
+}
+
+// IsScrolledToBottom reports whether the element is scrolled to within threshold pixels of the bottom.
+func (e *elementS) IsScrolledToBottom(threshold float64) bool {
+	return e.ScrollHeight()-e.ScrollTop()-e.ClientHeight() <= threshold
+}
+
+// OnScroll adds a passive scroll listener that reports the position and
+// which way it moved since the previous scroll event.
+func (e *elementS) OnScroll(listener func(ScrollInfoT)) EventListenerI {
+	var mutex sync.Mutex
+	lastTop, lastLeft := e.ScrollTop(), e.ScrollLeft()
+
+	opts := ListenerOptionsT{Passive: true}
+	return e.AddEventListenerWithOptions("scroll", opts, func(EventI) {
+		top, left := e.ScrollTop(), e.ScrollLeft()
+
+		mutex.Lock()
+		dTop, dLeft := top-lastTop, left-lastLeft
+		lastTop, lastLeft = top, left
+		mutex.Unlock()
+
+		listener(ScrollInfoT{
+			Top:       top,
+			Left:      left,
+			DeltaTop:  dTop,
+			DeltaLeft: dLeft,
+			Direction: scrollDirection(dTop, dLeft),
+			AtTop:     top <= 0,
+			AtBottom:  e.IsScrolledToBottom(1),
+		})
+	})
+}
+
+////
+////
+////
+
+// AutoScrollS keeps a scrolling element pinned to its bottom while content
+// is added, as long as the user has not scrolled away from the bottom.
+// Scrolling back down pins it again. Meant for logs and chat views.
+type AutoScrollS struct {
+	ElementI
+
+	Threshold float64 // distance from the bottom, in pixels, that still counts as the bottom
+
+	mutex    sync.Mutex
+	pinned   bool
+	listener EventListenerI
+}
+
+func NewAutoScroll(e ElementI, threshold float64) *AutoScrollS {
+	ret := &AutoScrollS{
+		ElementI:  e,
+		Threshold: threshold,
+		pinned:    true,
+	}
+	ret.listener = e.OnScroll(func(info ScrollInfoT) {
+		pinned := e.IsScrolledToBottom(ret.Threshold)
+		ret.mutex.Lock()
+		ret.pinned = pinned
+		ret.mutex.Unlock()
+	})
+	return ret
+}
+
+// Pinned reports whether new content will scroll the element to the bottom.
+func (s *AutoScrollS) Pinned() bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.pinned
+}
+
+// Update runs fn, which should add content, and then scrolls to the bottom
+// if the element was pinned.
+func (s *AutoScrollS) Update(fn func()) {
+	pinned := s.Pinned() || s.IsScrolledToBottom(s.Threshold)
+	fn()
+	if pinned {
+		s.ScrollToBottom(ScrollBehavior_Auto)
+	}
+}
+
+// Append adds children and keeps the bottom pinned.
+func (s *AutoScrollS) Append(children ...ElementI) {
+	s.Update(func() {
+		s.AppendChildren(children...)
+	})
+}
+
+// Release removes the scroll listener.
+func (s *AutoScrollS) Release() {
+	s.RemoveEventListener(s.listener)
+}
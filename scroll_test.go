@@ -0,0 +1,47 @@
+package dom
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestScrollDirection(t *testing.T) {
+	tests := []struct {
+		dTop, dLeft float64
+		expected    ScrollDirection
+	}{
+		{0, 0, ScrollDirection_None},
+		{-10, 0, ScrollDirection_Up},
+		{10, 0, ScrollDirection_Down},
+		{0, -10, ScrollDirection_Left},
+		{0, 10, ScrollDirection_Right},
+		{-5, 20, ScrollDirection_Right},
+		{-20, 5, ScrollDirection_Up},
+		{10, -10, ScrollDirection_Down}, // ties go to the vertical direction
+	}
+	for _, test := range tests {
+		if got := scrollDirection(test.dTop, test.dLeft); got != test.expected {
+			t.Errorf("expected: %+v but found: %+v\n", test.expected, got)
+		}
+	}
+}
+
+func TestScrollOptions(t *testing.T) {
+	got := scrollToOptions(4, 8, "")
+	expected := map[string]any{"left": 4.0, "top": 8.0}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected: %+v but found: %+v\n", expected, got)
+	}
+
+	got = scrollToOptions(4, 8, ScrollBehavior_Smooth)
+	expected["behavior"] = "smooth"
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected: %+v but found: %+v\n", expected, got)
+	}
+
+	got = ScrollIntoViewOptionsT{Block: ScrollLogicalPosition_Center}.toMap()
+	expected = map[string]any{"block": "center"}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected: %+v but found: %+v\n", expected, got)
+	}
+}
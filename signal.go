@@ -0,0 +1,310 @@
+package dom
+
+import (
+	"reflect"
+	"sync"
+)
+
+/*
+Signals hold a value and notify subscribers when it changes. The Bind
+helpers keep part of an element in sync with a signal, applying changes at
+most once per animation frame no matter how often the signal is set.
+
+	speed := NewSignal(0.0)
+	label := MapSignal(speed, func(v float64) string { return fmt.Sprintf("%.1f m/s", v) })
+	BindText(el, label)
+	speed.Set(12.5)
+
+Bindings end when the element is removed. A computed signal stays
+subscribed to the signals it depends on until it is stopped, so stop the
+ones that are no longer needed:
+
+	defer label.Stop()
+*/
+
+// ObservableI is anything a computation can depend on.
+type ObservableI interface {
+	// Watch calls fn after every change and returns a function that stops watching.
+	Watch(fn func()) (stop func())
+}
+
+// SignalI is the read side of a signal. Computed signals only implement this.
+type SignalI[T any] interface {
+	ObservableI
+	Get() T
+	// Subscribe calls fn with the new value after every change and
+	// returns a function that unsubscribes.
+	Subscribe(fn func(T)) (unsubscribe func())
+}
+
+type SignalS[T any] struct {
+	mutex       sync.Mutex
+	value       T
+	version     int  // counts the changes
+	notifying   bool // an Update is calling the subscribers
+	subscribers map[int]func(T)
+	nextID      int
+}
+
+var _ SignalI[int] = &SignalS[int]{}
+
+func NewSignal[T any](initial T) *SignalS[T] {
+	ret := &SignalS[T]{
+		value:       initial,
+		subscribers: map[int]func(T){},
+	}
+	return ret
+}
+
+func (s *SignalS[T]) Get() T {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.value
+}
+
+// Set stores v and notifies the subscribers, unless v equals the current value.
+func (s *SignalS[T]) Set(v T) {
+	s.Update(func(T) T { return v })
+}
+
+// Update sets the value to the result of fn applied to the current value.
+// Other updates wait until fn returns, so none are lost, which also means
+// fn must not use the signal itself.
+//
+// Subscribers are called one change at a time, in order. A change made
+// while the subscribers are being called, by another goroutine or by a
+// subscriber, is left to the Update already calling them, which calls them
+// again with the latest value once done. Subscribers may skip values in
+// between, but always end with the latest one.
+func (s *SignalS[T]) Update(fn func(T) T) {
+	s.mutex.Lock()
+	v := fn(s.value)
+	if reflect.DeepEqual(s.value, v) {
+		s.mutex.Unlock()
+		return
+	}
+	s.value = v
+	s.version++
+	if s.notifying {
+		s.mutex.Unlock()
+		return
+	}
+	s.notifying = true
+
+	for {
+		version, value := s.version, s.value
+		subscribers := make([]func(T), 0, len(s.subscribers))
+		for _, subscriber := range s.subscribers {
+			subscribers = append(subscribers, subscriber)
+		}
+		s.mutex.Unlock()
+
+		for _, subscriber := range subscribers {
+			subscriber(value)
+		}
+
+		s.mutex.Lock()
+		if s.version == version {
+			s.notifying = false
+			s.mutex.Unlock()
+			return
+		}
+	}
+}
+
+func (s *SignalS[T]) Subscribe(fn func(T)) (unsubscribe func()) {
+	s.mutex.Lock()
+	id := s.nextID
+	s.nextID++
+	s.subscribers[id] = fn
+	s.mutex.Unlock()
+
+	return func() {
+		s.mutex.Lock()
+		delete(s.subscribers, id)
+		s.mutex.Unlock()
+	}
+}
+
+func (s *SignalS[T]) Watch(fn func()) (stop func()) {
+	return s.Subscribe(func(T) { fn() })
+}
+
+// ComputedS is a signal whose value is derived from other signals.
+type ComputedS[T any] struct {
+	signal *SignalS[T]
+	stops  []func()
+	once   sync.Once
+}
+
+var _ SignalI[int] = &ComputedS[int]{}
+
+// Computed returns a signal holding the result of fn, recomputed whenever
+// one of deps changes, until Stop is called.
+func Computed[T any](fn func() T, deps ...ObservableI) *ComputedS[T] {
+	ret := &ComputedS[T]{signal: NewSignal(fn())}
+	for _, dep := range deps {
+		ret.stops = append(ret.stops, dep.Watch(func() {
+			ret.signal.Set(fn())
+		}))
+	}
+	return ret
+}
+
+// MapSignal returns a signal holding fn applied to the value of src.
+func MapSignal[T, U any](src SignalI[T], fn func(T) U) *ComputedS[U] {
+	return Computed(func() U { return fn(src.Get()) }, src)
+}
+
+func (c *ComputedS[T]) Get() T {
+	return c.signal.Get()
+}
+
+func (c *ComputedS[T]) Subscribe(fn func(T)) (unsubscribe func()) {
+	return c.signal.Subscribe(fn)
+}
+
+func (c *ComputedS[T]) Watch(fn func()) (stop func()) {
+	return c.signal.Watch(fn)
+}
+
+// Stop stops watching the signals the value is derived from, which keeps
+// the current value from then on. Calling it again does nothing.
+func (c *ComputedS[T]) Stop() {
+	c.once.Do(func() {
+		for _, stop := range c.stops {
+			stop()
+		}
+	})
+}
+
+////
+////
+////
+
+// BindText keeps the element's text content equal to the signal. Like the
+// other Bind helpers, the binding ends when the element is removed.
+func BindText(e ElementI, sig SignalI[string]) (unbind func()) {
+	return bind(e, sig, e.SetTextContent)
+}
+
+// BindAttr keeps attribute name equal to the signal.
+func BindAttr(e ElementI, name string, sig SignalI[string]) (unbind func()) {
+	return bind(e, sig, func(v string) {
+		e.SetAttribute(name, v)
+	})
+}
+
+// BindClass adds token to the element's classList while the signal is true.
+func BindClass(e ElementI, token string, sig SignalI[bool]) (unbind func()) {
+	return bind(e, sig, func(v bool) {
+		if v {
+			e.Class().Add(token)
+		} else {
+			e.Class().Remove(token)
+		}
+	})
+}
+
+// BindStyle keeps style property name equal to the signal. The name is the
+// JavaScript property name, such as "backgroundColor".
+func BindStyle(e ElementI, name string, sig SignalI[string]) (unbind func()) {
+	return bind(e, sig, func(v string) {
+		e.Style().Set(name, v)
+	})
+}
+
+// bind applies the current value right away and queues every later change
+// for the next animation frame, until unbind is called or e is removed.
+func bind[T any](e ElementI, sig SignalI[T], apply func(T)) (unbind func()) {
+	b := &bindingS{
+		apply: func() { apply(sig.Get()) },
+	}
+	b.apply()
+
+	unsubscribe := sig.Subscribe(func(T) {
+		bindingQueue.add(b)
+	})
+
+	var once sync.Once
+	release := func() {
+		once.Do(func() {
+			unsubscribe()
+			bindingQueue.remove(b)
+		})
+	}
+	cancelRemove := e.OnRemove(release)
+
+	return func() {
+		cancelRemove()
+		release()
+	}
+}
+
+type bindingS struct {
+	apply func()
+}
+
+// bindingQueueT collects the bindings that changed since the last frame.
+type bindingQueueT struct {
+	mutex     sync.Mutex
+	pending   []*bindingS
+	queued    map[*bindingS]bool
+	scheduled bool
+}
+
+var bindingQueue = bindingQueueT{queued: map[*bindingS]bool{}}
+
+func (q *bindingQueueT) add(b *bindingS) {
+	q.mutex.Lock()
+	if q.queued[b] {
+		q.mutex.Unlock()
+		return
+	}
+	q.queued[b] = true
+	q.pending = append(q.pending, b)
+	if q.scheduled {
+		q.mutex.Unlock()
+		return
+	}
+	q.scheduled = true
+	q.mutex.Unlock()
+
+	Window.RequestAnimationFrame(func(float64) {
+		q.flush()
+	})
+}
+
+func (q *bindingQueueT) remove(b *bindingS) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+	if !q.queued[b] {
+		return
+	}
+	delete(q.queued, b)
+	for i, pending := range q.pending {
+		if pending == b {
+			q.pending = append(q.pending[:i], q.pending[i+1:]...)
+			break
+		}
+	}
+}
+
+func (q *bindingQueueT) flush() {
+	q.mutex.Lock()
+	pending := q.pending
+	q.pending = nil
+	q.queued = map[*bindingS]bool{}
+	q.scheduled = false
+	q.mutex.Unlock()
+
+	for _, b := range pending {
+		b.apply()
+	}
+}
+
+// FlushBindings applies pending binding updates right away instead of
+// waiting for the next animation frame.
+func FlushBindings() {
+	bindingQueue.flush()
+}
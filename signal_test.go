@@ -0,0 +1,155 @@
+package dom
+
+import (
+	"reflect"
+	"strconv"
+	"sync"
+	"testing"
+)
+
+func TestSignalSubscribe(t *testing.T) {
+	s := NewSignal(1)
+	var got []int
+	unsubscribe := s.Subscribe(func(v int) { got = append(got, v) })
+
+	s.Set(2)
+	s.Set(2)
+	s.Update(func(v int) int { return v * 10 })
+	unsubscribe()
+	s.Set(3)
+
+	expected := []int{2, 20}
+	if !reflect.DeepEqual(expected, got) {
+		t.Errorf("expected: %+v but found: %+v\n", expected, got)
+	}
+	if s.Get() != 3 {
+		t.Errorf("expected: %d but found: %d\n", 3, s.Get())
+	}
+}
+
+func TestComputed(t *testing.T) {
+	a := NewSignal(2)
+	b := NewSignal(3)
+	sum := Computed(func() int { return a.Get() + b.Get() }, a, b)
+	label := MapSignal(sum, strconv.Itoa)
+
+	if sum.Get() != 5 {
+		t.Errorf("expected: %d but found: %d\n", 5, sum.Get())
+	}
+	a.Set(10)
+	b.Set(1)
+	if sum.Get() != 11 {
+		t.Errorf("expected: %d but found: %d\n", 11, sum.Get())
+	}
+	if label.Get() != "11" {
+		t.Errorf("expected: %s but found: %s\n", "11", label.Get())
+	}
+}
+
+func TestSignalConcurrentUpdates(t *testing.T) {
+	s := NewSignal(0)
+	var wg sync.WaitGroup
+	for range 100 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s.Update(func(v int) int { return v + 1 })
+		}()
+	}
+	wg.Wait()
+	if s.Get() != 100 {
+		t.Errorf("expected: %d but found: %d\n", 100, s.Get())
+	}
+}
+
+func TestComputedStop(t *testing.T) {
+	a := NewSignal(1)
+	double := MapSignal(a, func(v int) int { return v * 2 })
+	double.Stop()
+	double.Stop()
+
+	a.Set(5)
+	if double.Get() != 2 {
+		t.Errorf("expected: %d but found: %d\n", 2, double.Get())
+	}
+	a.mutex.Lock()
+	left := len(a.subscribers)
+	a.mutex.Unlock()
+	if left != 0 {
+		t.Errorf("expected: %d but found: %d\n", 0, left)
+	}
+}
+
+func TestBindingCoalescesPerFrame(t *testing.T) {
+	s := NewSignal("a")
+	var applied []string
+	e := NewElement(valueS{})
+	defer e.Remove()
+	unbind := bind[string](e, s, func(v string) { applied = append(applied, v) })
+
+	s.Set("b")
+	s.Set("c")
+	s.Set("d")
+	FlushBindings()
+
+	expected := []string{"a", "d"}
+	if !reflect.DeepEqual(expected, applied) {
+		t.Errorf("expected: %+v but found: %+v\n", expected, applied)
+	}
+
+	s.Set("e")
+	unbind()
+	FlushBindings()
+	if !reflect.DeepEqual(expected, applied) {
+		t.Errorf("expected no update after unbind but found: %+v\n", applied)
+	}
+}
+
+func TestBindingEndsWithElement(t *testing.T) {
+	s := NewSignal("a")
+	e := NewElement(valueS{})
+	BindText(e, s)
+	unbind := BindAttr(e, "title", s)
+
+	e.Remove()
+	unbind()
+	s.mutex.Lock()
+	left := len(s.subscribers)
+	s.mutex.Unlock()
+	if left != 0 {
+		t.Errorf("expected: %d but found: %d\n", 0, left)
+	}
+}
+
+func TestSignalNotifiesInOrder(t *testing.T) {
+	s := NewSignal(0)
+	first, release := make(chan struct{}), make(chan struct{})
+	var mutex sync.Mutex
+	var got []int
+	s.Subscribe(func(v int) {
+		if v == 1 {
+			close(first)
+			<-release
+		}
+		mutex.Lock()
+		got = append(got, v)
+		mutex.Unlock()
+	})
+
+	done := make(chan struct{})
+	go func() {
+		s.Set(1)
+		close(done)
+	}()
+	// a change while 1 is still being delivered comes after it
+	<-first
+	s.Set(2)
+	close(release)
+	<-done
+
+	mutex.Lock()
+	defer mutex.Unlock()
+	if expected := []int{1, 2}; !reflect.DeepEqual(expected, got) {
+		t.Errorf("expected: %+v but found: %+v\n", expected, got)
+	}
+}
@@ -90,6 +90,9 @@ type ValueI interface {
 	// Add an event listener to things that can do that such as the window and html elements
 	AddEventListener(typ string, useCapture bool, listener func(EventI)) EventListenerI
 
+	// Add an event listener with control over how the event is handled before the listener runs
+	AddEventListenerWithOptions(typ string, opts ListenerOptionsT, listener func(EventI)) EventListenerI
+
 	// remove an event listener to things that they have been added to before
 	RemoveEventListener(listener EventListenerI)
 
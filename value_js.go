@@ -168,20 +168,33 @@ func (s valueS) InstanceOf(t ValueI) bool {
 }
 
 func (s valueS) AddEventListener(typ string, useCapture bool, listener func(EventI)) EventListenerI {
+	return s.AddEventListenerWithOptions(typ, ListenerOptionsT{Capture: useCapture}, listener)
+}
+
+func (s valueS) AddEventListenerWithOptions(typ string, opts ListenerOptionsT, listener func(EventI)) EventListenerI {
 	wrapperJsFunc := NewFuncForJavascript(func(this ValueI, args []ValueI) any {
 		arg := args[0]
 		var e *eventS
 		if !arg.IsNull() && !arg.IsUndefined() {
 			jsArg := arg.(valueS)
 			e = &eventS{ValueI: valueS{jsValue: jsArg.jsValue}}
+			if opts.PreventDefault {
+				e.PreventDefault()
+			}
+			if opts.StopPropagation {
+				e.StopPropagation()
+			}
 		}
-		go listener(e)
+		opts.deliver(func() { listener(e) })
 		return nil
 	})
 
-	s.Call("addEventListener", typ, wrapperJsFunc, useCapture)
+	s.Call("addEventListener", typ, wrapperJsFunc, map[string]any{
+		"capture": opts.Capture,
+		"passive": opts.Passive,
+	})
 
-	ret := NewEventListener(wrapperJsFunc, typ, useCapture)
+	ret := NewEventListener(wrapperJsFunc, typ, opts.Capture)
 	return ret
 }
 
@@ -249,6 +262,18 @@ func ValueOf(i any) valueS {
 
 // valueOf recursively returns a new value.
 func valueOf(v reflect.Value) js.Value {
+	// values that already wrap JavaScript ones, when nested in maps or slices
+	if v.Kind() == reflect.Struct && v.CanInterface() {
+		switch x := v.Interface().(type) {
+		case js.Value:
+			return x
+		case valueS:
+			return x.jsValue
+		case funcS:
+			return x.Value
+		}
+	}
+
 	switch v.Kind() {
 	case reflect.Ptr, reflect.Interface:
 		return valueOfPointerOrInterface(v)
@@ -326,3 +351,14 @@ func nameOf(sf reflect.StructField) string {
 	}
 	return name
 }
+
+// bytesFromValue copies the contents of an ArrayBuffer or Uint8Array.
+func bytesFromValue(val ValueI) []byte {
+	arr := val.(valueS).jsValue
+	if !arr.InstanceOf(js.Global().Get("Uint8Array")) {
+		arr = js.Global().Get("Uint8Array").New(arr)
+	}
+	out := make([]byte, arr.Length())
+	js.CopyBytesToGo(out, arr)
+	return out
+}
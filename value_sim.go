@@ -2,6 +2,8 @@
 
 package dom
 
+import "strings"
+
 var (
 	// null   = "null"
 	// object = "object"
@@ -10,6 +12,7 @@ var (
 
 type valueS struct {
 	jsValue string
+	tagName string // of elements made with createElement, so code reading the tag can be tested
 }
 
 var _ ValueI = valueS{}
@@ -44,6 +47,9 @@ func (s valueS) Type() Type {
 // Get returns the JavaScript property p of value v.
 // It panics if v is not a JavaScript object.
 func (s valueS) Get(p string) ValueI {
+	if p == "tagName" {
+		return valueS{jsValue: s.tagName}
+	}
 	return valueS{}
 }
 
@@ -80,6 +86,11 @@ func (s valueS) Length() int {
 // It panics if v has no method m.
 // The arguments get mapped to JavaScript values according to the ValueOf function.
 func (s valueS) Call(m string, args ...any) ValueI {
+	if m == "createElement" && len(args) == 1 {
+		if tag, ok := args[0].(string); ok {
+			return valueS{tagName: strings.ToUpper(tag)}
+		}
+	}
 	return valueS{}
 }
 
@@ -135,7 +146,12 @@ func (s valueS) InstanceOf(t ValueI) bool {
 
 // Add an event listener to things that can do that such as the window and html elements
 func (s valueS) AddEventListener(typ string, useCapture bool, listener func(EventI)) EventListenerI {
-	return nil
+	return NewEventListener(funcS{}, typ, useCapture)
+}
+
+// Add an event listener with control over how the event is handled before the listener runs
+func (s valueS) AddEventListenerWithOptions(typ string, opts ListenerOptionsT, listener func(EventI)) EventListenerI {
+	return NewEventListener(funcS{}, typ, opts.Capture)
 }
 
 // remove an event listener to things that they have been added to before
@@ -147,3 +163,8 @@ func (s valueS) RemoveEventListener(listener EventListenerI) {
 func (s valueS) DispatchEvent(event EventI) bool {
 	return false
 }
+
+// bytesFromValue copies the contents of an ArrayBuffer or Uint8Array.
+func bytesFromValue(val ValueI) []byte {
+	return nil
+}
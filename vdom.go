@@ -0,0 +1,521 @@
+package dom
+
+import (
+	"reflect"
+	"sync"
+)
+
+/*
+A virtual DOM. Describe the UI as a VNode tree, hand it to VDomS.Render, and
+only the differences from the previous render are applied to the real
+elements. Elements that survive a render keep their focus, scroll position
+and input state.
+
+	vdom := NewVDom(Body)
+	vdom.Render(NewVNode("ul",
+		NewVNode("li", NewVText("one")).WithKey("1"),
+		NewVNode("li", NewVText("two")).WithKey("2"),
+	))
+
+A VNode tree belongs to the render it was passed to. Build a fresh tree for
+every render instead of modifying or reusing the previous one.
+*/
+
+// VNode describes an element, or a text node when Tag is empty.
+type VNode struct {
+	Tag  string
+	Key  string // identifies a child among its siblings so it can be moved instead of recreated
+	Text string // only used by text nodes
+
+	Attrs    map[string]string
+	Props    map[string]any    // JavaScript properties such as "value" or "checked"
+	Styles   map[string]string // JavaScript style property names such as "backgroundColor"
+	Events   map[string]func(EventI)
+	Children []*VNode
+
+	el        ElementI
+	listeners map[string]*vListener
+}
+
+func NewVNode(tag string, children ...*VNode) *VNode {
+	ret := &VNode{
+		Tag:      tag,
+		Children: children,
+	}
+	return ret
+}
+
+func NewVText(text string) *VNode {
+	ret := &VNode{
+		Text: text,
+	}
+	return ret
+}
+
+func (v *VNode) WithKey(key string) *VNode {
+	v.Key = key
+	return v
+}
+
+func (v *VNode) Attr(name, value string) *VNode {
+	if v.Attrs == nil {
+		v.Attrs = map[string]string{}
+	}
+	v.Attrs[name] = value
+	return v
+}
+
+func (v *VNode) Prop(name string, value any) *VNode {
+	if v.Props == nil {
+		v.Props = map[string]any{}
+	}
+	v.Props[name] = value
+	return v
+}
+
+func (v *VNode) Style(name, value string) *VNode {
+	if v.Styles == nil {
+		v.Styles = map[string]string{}
+	}
+	v.Styles[name] = value
+	return v
+}
+
+func (v *VNode) On(typ string, listener func(EventI)) *VNode {
+	if v.Events == nil {
+		v.Events = map[string]func(EventI){}
+	}
+	v.Events[typ] = listener
+	return v
+}
+
+func (v *VNode) Append(children ...*VNode) *VNode {
+	v.Children = append(v.Children, children...)
+	return v
+}
+
+func (v *VNode) IsText() bool {
+	return v.Tag == ""
+}
+
+// Element returns the real node this VNode was rendered to, or nil if it has not been rendered.
+func (v *VNode) Element() ElementI {
+	return v.el
+}
+
+// vListener is the single real listener added for an event type. Re-renders
+// swap the Go function it calls rather than the DOM listener itself.
+type vListener struct {
+	mutex    sync.Mutex
+	fn       func(EventI)
+	listener EventListenerI
+}
+
+func (l *vListener) handle(e EventI) {
+	l.mutex.Lock()
+	fn := l.fn
+	l.mutex.Unlock()
+	if fn != nil {
+		fn(e)
+	}
+}
+
+func (l *vListener) swap(fn func(EventI)) {
+	l.mutex.Lock()
+	l.fn = fn
+	l.mutex.Unlock()
+}
+
+////
+////
+////
+
+type PatchOp int
+
+const (
+	PatchOp_Create         PatchOp = iota // create Node and insert it into Parent before Before
+	PatchOp_Remove                        // remove Node from Parent
+	PatchOp_Replace                       // replace Node with New in Parent
+	PatchOp_Move                          // move Node before Before in Parent
+	PatchOp_SetText                       // set the text of text node Node to Value
+	PatchOp_SetAttr                       // set attribute Name to Value
+	PatchOp_RemoveAttr                    // remove attribute Name
+	PatchOp_SetProp                       // set JavaScript property Name to Value
+	PatchOp_RemoveProp                    // clear JavaScript property Name
+	PatchOp_SetStyle                      // set style property Name to Value
+	PatchOp_RemoveStyle                   // clear style property Name
+	PatchOp_SetListener                   // add the listener for event Name, or swap the Go function behind it
+	PatchOp_RemoveListener                // remove the listener for event Name
+)
+
+func (op PatchOp) String() string {
+	switch op {
+	case PatchOp_Create:
+		return "create"
+	case PatchOp_Remove:
+		return "remove"
+	case PatchOp_Replace:
+		return "replace"
+	case PatchOp_Move:
+		return "move"
+	case PatchOp_SetText:
+		return "setText"
+	case PatchOp_SetAttr:
+		return "setAttr"
+	case PatchOp_RemoveAttr:
+		return "removeAttr"
+	case PatchOp_SetProp:
+		return "setProp"
+	case PatchOp_RemoveProp:
+		return "removeProp"
+	case PatchOp_SetStyle:
+		return "setStyle"
+	case PatchOp_RemoveStyle:
+		return "removeStyle"
+	case PatchOp_SetListener:
+		return "setListener"
+	case PatchOp_RemoveListener:
+		return "removeListener"
+	default:
+		panic("bad patch op")
+	}
+}
+
+// Patch is a single mutation found by Diff. A nil Parent means the container
+// the tree is rendered into, and a nil Before means the end of Parent.
+type Patch struct {
+	Op     PatchOp
+	Node   *VNode
+	New    *VNode
+	Parent *VNode
+	Before *VNode
+	Name   string
+	Value  any
+}
+
+// Diff returns the patches that turn the old tree into the new one.
+// Nodes of the new tree that match a node of the old tree take over its
+// real element, so Diff must only be called once per pair of trees.
+func Diff(old, new *VNode) []Patch {
+	d := differ{}
+	d.node(nil, old, new)
+	return d.patches
+}
+
+type differ struct {
+	patches []Patch
+}
+
+func (d *differ) add(p Patch) {
+	d.patches = append(d.patches, p)
+}
+
+func (d *differ) node(parent, old, new *VNode) {
+	switch {
+	case old == nil && new == nil:
+		return
+	case old == nil:
+		d.add(Patch{Op: PatchOp_Create, Node: new, Parent: parent})
+		return
+	case new == nil:
+		d.add(Patch{Op: PatchOp_Remove, Node: old, Parent: parent})
+		return
+	case old.Tag != new.Tag || old.Key != new.Key:
+		d.add(Patch{Op: PatchOp_Replace, Node: old, New: new, Parent: parent})
+		return
+	}
+
+	new.el = old.el
+	new.listeners = old.listeners
+
+	if new.IsText() {
+		if old.Text != new.Text {
+			d.add(Patch{Op: PatchOp_SetText, Node: new, Value: new.Text})
+		}
+		return
+	}
+
+	diffStrings(old.Attrs, new.Attrs, func(name, value string, ok bool) {
+		if ok {
+			d.add(Patch{Op: PatchOp_SetAttr, Node: new, Name: name, Value: value})
+		} else {
+			d.add(Patch{Op: PatchOp_RemoveAttr, Node: new, Name: name})
+		}
+	})
+
+	for name := range old.Props {
+		if _, ok := new.Props[name]; !ok {
+			d.add(Patch{Op: PatchOp_RemoveProp, Node: new, Name: name})
+		}
+	}
+	for name, value := range new.Props {
+		if oldValue, ok := old.Props[name]; !ok || !reflect.DeepEqual(oldValue, value) {
+			d.add(Patch{Op: PatchOp_SetProp, Node: new, Name: name, Value: value})
+		}
+	}
+
+	diffStrings(old.Styles, new.Styles, func(name, value string, ok bool) {
+		if ok {
+			d.add(Patch{Op: PatchOp_SetStyle, Node: new, Name: name, Value: value})
+		} else {
+			d.add(Patch{Op: PatchOp_RemoveStyle, Node: new, Name: name})
+		}
+	})
+
+	for typ := range old.Events {
+		if _, ok := new.Events[typ]; !ok {
+			d.add(Patch{Op: PatchOp_RemoveListener, Node: new, Name: typ})
+		}
+	}
+	for typ := range new.Events {
+		d.add(Patch{Op: PatchOp_SetListener, Node: new, Name: typ})
+	}
+
+	d.children(new, old.Children, new.Children)
+}
+
+// diffStrings calls fn for every entry that was added or changed (ok is true)
+// or removed (ok is false).
+func diffStrings(old, new map[string]string, fn func(name, value string, ok bool)) {
+	for name := range old {
+		if _, ok := new[name]; !ok {
+			fn(name, "", false)
+		}
+	}
+	for name, value := range new {
+		if oldValue, ok := old[name]; !ok || oldValue != value {
+			fn(name, value, true)
+		}
+	}
+}
+
+// children reconciles two child lists. Keyed children are matched by key and
+// unkeyed children by their order. Matched children that are part of the
+// longest run already in the right order stay put, everything else is moved.
+func (d *differ) children(parent *VNode, olds, news []*VNode) {
+	matched := make([]int, len(news))
+	used := make([]bool, len(olds))
+
+	keyed := map[string]int{}
+	var unkeyed []int
+	for j, old := range olds {
+		if old.Key != "" {
+			keyed[old.Key] = j
+		} else {
+			unkeyed = append(unkeyed, j)
+		}
+	}
+
+	for i, new := range news {
+		matched[i] = -1
+		if new.Key != "" {
+			if j, ok := keyed[new.Key]; ok {
+				matched[i] = j
+				used[j] = true
+				delete(keyed, new.Key)
+			}
+		} else if len(unkeyed) > 0 {
+			matched[i] = unkeyed[0]
+			used[unkeyed[0]] = true
+			unkeyed = unkeyed[1:]
+		}
+	}
+
+	for j, old := range olds {
+		if !used[j] {
+			d.add(Patch{Op: PatchOp_Remove, Node: old, Parent: parent})
+		}
+	}
+
+	for i, new := range news {
+		if matched[i] >= 0 {
+			d.node(parent, olds[matched[i]], new)
+		}
+	}
+
+	stay := longestIncreasingRun(matched)
+
+	var before *VNode
+	for i := len(news) - 1; i >= 0; i-- {
+		new := news[i]
+		if matched[i] < 0 {
+			d.add(Patch{Op: PatchOp_Create, Node: new, Parent: parent, Before: before})
+		} else if !stay[i] {
+			d.add(Patch{Op: PatchOp_Move, Node: new, Parent: parent, Before: before})
+		}
+		before = new
+	}
+}
+
+// longestIncreasingRun marks the positions of the longest strictly increasing
+// subsequence of seq, ignoring negative entries.
+func longestIncreasingRun(seq []int) []bool {
+	// tails[k] is the position in seq ending the best run of length k+1
+	tails := []int{}
+	prev := make([]int, len(seq))
+	for i, v := range seq {
+		prev[i] = -1
+		if v < 0 {
+			continue
+		}
+		lo, hi := 0, len(tails)
+		for lo < hi {
+			mid := (lo + hi) / 2
+			if seq[tails[mid]] < v {
+				lo = mid + 1
+			} else {
+				hi = mid
+			}
+		}
+		if lo > 0 {
+			prev[i] = tails[lo-1]
+		}
+		if lo == len(tails) {
+			tails = append(tails, i)
+		} else {
+			tails[lo] = i
+		}
+	}
+
+	ret := make([]bool, len(seq))
+	if len(tails) == 0 {
+		return ret
+	}
+	for i := tails[len(tails)-1]; i >= 0; i = prev[i] {
+		ret[i] = true
+	}
+	return ret
+}
+
+////
+////
+////
+
+// VDomS renders VNode trees into a container element.
+type VDomS struct {
+	container ElementI
+	current   *VNode
+}
+
+func NewVDom(container ElementI) *VDomS {
+	ret := &VDomS{
+		container: container,
+	}
+	return ret
+}
+
+// Current returns the tree from the last render.
+func (s *VDomS) Current() *VNode {
+	return s.current
+}
+
+// Render diffs next against the previous render and applies the patches.
+// Rendering nil removes everything rendered so far.
+func (s *VDomS) Render(next *VNode) {
+	patches := Diff(s.current, next)
+	for _, p := range patches {
+		s.apply(p)
+	}
+	s.current = next
+}
+
+func (s *VDomS) parentElement(parent *VNode) ElementI {
+	if parent == nil {
+		return s.container
+	}
+	return parent.el
+}
+
+func (s *VDomS) apply(p Patch) {
+	switch p.Op {
+	case PatchOp_Create:
+		el := createVNode(p.Node)
+		var before ElementI
+		if p.Before != nil {
+			before = p.Before.el
+		}
+		s.parentElement(p.Parent).InsertBefore(el, before)
+	case PatchOp_Remove:
+		s.parentElement(p.Parent).RemoveChild(p.Node.el)
+		p.Node.el.Remove()
+	case PatchOp_Replace:
+		el := createVNode(p.New)
+		s.parentElement(p.Parent).ReplaceChild(el, p.Node.el)
+		p.Node.el.Remove()
+	case PatchOp_Move:
+		var before ElementI
+		if p.Before != nil {
+			before = p.Before.el
+		}
+		s.parentElement(p.Parent).InsertBefore(p.Node.el, before)
+	case PatchOp_SetText:
+		p.Node.el.SetTextContent(p.Value.(string))
+	case PatchOp_SetAttr:
+		p.Node.el.SetAttribute(p.Name, p.Value.(string))
+	case PatchOp_RemoveAttr:
+		p.Node.el.RemoveAttribute(p.Name)
+	case PatchOp_SetProp:
+		p.Node.el.Underlying().Set(p.Name, p.Value)
+	case PatchOp_RemoveProp:
+		p.Node.el.Underlying().Set(p.Name, nil)
+	case PatchOp_SetStyle:
+		p.Node.el.Style().Set(p.Name, p.Value.(string))
+	case PatchOp_RemoveStyle:
+		p.Node.el.Style().Set(p.Name, "")
+	case PatchOp_SetListener:
+		setVListener(p.Node, p.Name)
+	case PatchOp_RemoveListener:
+		if l, ok := p.Node.listeners[p.Name]; ok {
+			p.Node.el.RemoveEventListener(l.listener)
+			delete(p.Node.listeners, p.Name)
+		}
+	}
+}
+
+// createVNode builds the real nodes for v and its subtree.
+func createVNode(v *VNode) ElementI {
+	if v.IsText() {
+		v.el = Doc.CreateTextNode(v.Text)
+		return v.el
+	}
+
+	v.el = Doc.CreateElement(v.Tag)
+	for name, value := range v.Attrs {
+		v.el.SetAttribute(name, value)
+	}
+	for name, value := range v.Props {
+		v.el.Underlying().Set(name, value)
+	}
+	style := v.el.Style()
+	for name, value := range v.Styles {
+		style.Set(name, value)
+	}
+	v.listeners = nil
+	for typ := range v.Events {
+		setVListener(v, typ)
+	}
+
+	children := make([]ElementI, 0, len(v.Children))
+	for _, child := range v.Children {
+		children = append(children, createVNode(child))
+	}
+	if len(children) > 0 {
+		v.el.AppendChildren(children...)
+	}
+	return v.el
+}
+
+func setVListener(v *VNode, typ string) {
+	fn := v.Events[typ]
+	if l, ok := v.listeners[typ]; ok {
+		l.swap(fn)
+		return
+	}
+
+	if v.listeners == nil {
+		v.listeners = map[string]*vListener{}
+	}
+	l := &vListener{fn: fn}
+	l.listener = v.el.AddEventListener(typ, false, l.handle)
+	v.listeners[typ] = l
+}
@@ -0,0 +1,134 @@
+package dom
+
+import (
+	"reflect"
+	"testing"
+)
+
+func keyedList(keys ...string) *VNode {
+	list := NewVNode("ul")
+	for _, key := range keys {
+		list.Append(NewVNode("li", NewVText(key)).WithKey(key))
+	}
+	return list
+}
+
+func patchOps(patches []Patch) []PatchOp {
+	var ops []PatchOp
+	for _, p := range patches {
+		ops = append(ops, p.Op)
+	}
+	return ops
+}
+
+func TestDiffAttributesAndStyles(t *testing.T) {
+	old := NewVNode("div").Attr("role", "tab").Attr("title", "a").Style("padding", "4px")
+	new := NewVNode("div").Attr("role", "tab").Attr("title", "b").Style("margin", "2px")
+
+	patches := Diff(old, new)
+
+	found := map[PatchOp]string{}
+	for _, p := range patches {
+		found[p.Op] = p.Name
+	}
+	expected := map[PatchOp]string{
+		PatchOp_SetAttr:     "title",
+		PatchOp_RemoveStyle: "padding",
+		PatchOp_SetStyle:    "margin",
+	}
+	if !reflect.DeepEqual(expected, found) {
+		t.Errorf("expected: %+v but found: %+v\n", expected, found)
+	}
+}
+
+func TestDiffText(t *testing.T) {
+	patches := Diff(NewVNode("p", NewVText("a")), NewVNode("p", NewVText("b")))
+	if len(patches) != 1 || patches[0].Op != PatchOp_SetText || patches[0].Value != "b" {
+		t.Errorf("expected a single setText patch but found: %+v\n", patchOps(patches))
+	}
+
+	patches = Diff(NewVNode("p", NewVText("a")), NewVNode("p", NewVText("a")))
+	if len(patches) != 0 {
+		t.Errorf("expected no patches but found: %+v\n", patchOps(patches))
+	}
+}
+
+func TestDiffReplacesOnTagChange(t *testing.T) {
+	patches := Diff(NewVNode("div"), NewVNode("span"))
+	expected := []PatchOp{PatchOp_Replace}
+	if !reflect.DeepEqual(expected, patchOps(patches)) {
+		t.Errorf("expected: %+v but found: %+v\n", expected, patchOps(patches))
+	}
+}
+
+func TestDiffKeyedMoveIsMinimal(t *testing.T) {
+	patches := Diff(keyedList("a", "b", "c", "d"), keyedList("d", "a", "b", "c"))
+	expected := []PatchOp{PatchOp_Move}
+	if !reflect.DeepEqual(expected, patchOps(patches)) {
+		t.Errorf("expected: %+v but found: %+v\n", expected, patchOps(patches))
+	}
+	if patches[0].Node.Key != "d" || patches[0].Before.Key != "a" {
+		t.Errorf("expected d to move before a but found: %s before %s\n", patches[0].Node.Key, patches[0].Before.Key)
+	}
+}
+
+func TestDiffKeyedInsertAndRemove(t *testing.T) {
+	patches := Diff(keyedList("a", "b", "c"), keyedList("a", "x", "c"))
+	expected := []PatchOp{PatchOp_Remove, PatchOp_Create}
+	if !reflect.DeepEqual(expected, patchOps(patches)) {
+		t.Errorf("expected: %+v but found: %+v\n", expected, patchOps(patches))
+	}
+	if patches[0].Node.Key != "b" || patches[1].Node.Key != "x" || patches[1].Before.Key != "c" {
+		t.Errorf("unexpected patches: %+v\n", patches)
+	}
+}
+
+func TestVDomRenderKeepsElements(t *testing.T) {
+	container := Doc.CreateElement("div")
+	vdom := NewVDom(container)
+
+	vdom.Render(keyedList("a", "b", "c"))
+	list := container.FirstChild()
+	before := append([]ElementI{}, list.ChildNodes()...)
+	if len(before) != 3 {
+		t.Fatalf("expected 3 children but found: %d\n", len(before))
+	}
+
+	vdom.Render(keyedList("c", "b", "a"))
+	if container.FirstChild() != list {
+		t.Errorf("expected the list element to be kept\n")
+	}
+	after := list.ChildNodes()
+	expected := []ElementI{before[2], before[1], before[0]}
+	if !reflect.DeepEqual(expected, after) {
+		t.Errorf("expected: %+v but found: %+v\n", expected, after)
+	}
+
+	vdom.Render(nil)
+	if len(container.ChildNodes()) != 0 {
+		t.Errorf("expected an empty container but found: %d children\n", len(container.ChildNodes()))
+	}
+}
+
+func TestVDomListenerSwap(t *testing.T) {
+	container := Doc.CreateElement("div")
+	vdom := NewVDom(container)
+
+	calls := ""
+	vdom.Render(NewVNode("button").On("click", func(EventI) { calls += "a" }))
+	l := vdom.Current().listeners["click"]
+
+	vdom.Render(NewVNode("button").On("click", func(EventI) { calls += "b" }))
+	if vdom.Current().listeners["click"] != l {
+		t.Errorf("expected the DOM listener to be kept\n")
+	}
+	l.handle(nil)
+	if calls != "b" {
+		t.Errorf("expected: %s but found: %s\n", "b", calls)
+	}
+
+	vdom.Render(NewVNode("button"))
+	if len(vdom.Current().listeners) != 0 {
+		t.Errorf("expected the listener to be removed\n")
+	}
+}
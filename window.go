@@ -48,6 +48,7 @@ type WindowI interface {
 	ScrollTo(x, y int)
 	SetCursor(name string)
 	Stop()
+	RequestAnimationFrame(callback func(timestamp float64)) int // https://developer.mozilla.org/en-US/docs/Web/API/Window/requestAnimationFrame
 }
 
 type LocationI interface {
@@ -276,10 +277,26 @@ func (w *window) Stop() {
 	w.Call("stop")
 }
 
+// RequestAnimationFrame calls callback once before the next repaint. Unlike
+// event listeners, the callback runs synchronously inside the frame.
+func (w *window) RequestAnimationFrame(callback func(timestamp float64)) int {
+	var fn funcS
+	fn = NewFuncForJavascript(func(this ValueI, args []ValueI) any {
+		fn.Release()
+		callback(args[0].Float())
+		return nil
+	})
+	return w.Call("requestAnimationFrame", fn).Int()
+}
+
 func (s *window) AddEventListener(typ string, useCapture bool, listener func(EventI)) EventListenerI {
 	return s.Underlying().AddEventListener(typ, useCapture, listener)
 }
 
+func (s *window) AddEventListenerWithOptions(typ string, opts ListenerOptionsT, listener func(EventI)) EventListenerI {
+	return s.Underlying().AddEventListenerWithOptions(typ, opts, listener)
+}
+
 func (s *window) RemoveEventListener(listener EventListenerI) {
 	s.Underlying().RemoveEventListener(listener)
 }